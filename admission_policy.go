@@ -0,0 +1,278 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// How admission policy findings are enforced
+const (
+	enforcementWarn = "warn"
+	enforcementDeny = "deny"
+)
+
+// Status of tool results for experiments the admission policy rejected
+const admissionDeniedStatus = "admission_denied"
+
+// admissionPolicyFile is the YAML or JSON file named by LITMUS_ADMISSION_POLICY_FILE.
+type admissionPolicyFile struct {
+	Enforcement string          `json:"enforcement"`
+	Rules       []admissionRule `json:"rules"`
+}
+
+// admissionRule restricts the faults of experiments on the infrastructure in its scope. Every limit that is set
+// is checked against every fault of the experiment manifest.
+type admissionRule struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Enforcement string `json:"enforcement,omitempty"`
+	targetScope
+
+	DeniedFaults       []string `json:"deniedFaults,omitempty"`
+	AllowedFaults      []string `json:"allowedFaults,omitempty"`
+	MaxDurationSeconds int      `json:"maxDurationSeconds,omitempty"`
+	AllowedNamespaces  []string `json:"allowedNamespaces,omitempty"`
+	RequireProbes      bool     `json:"requireProbes,omitempty"`
+}
+
+// policyFinding is one violation of an admission rule by a fault of an experiment.
+type policyFinding struct {
+	Rule        string `json:"rule"`
+	Enforcement string `json:"enforcement"`
+	Check       string `json:"check"`
+	Fault       string `json:"fault"`
+	Step        string `json:"step,omitempty"`
+	Value       string `json:"value,omitempty"`
+	Limit       string `json:"limit,omitempty"`
+	Message     string `json:"message"`
+}
+
+// validate checks the rule and fills in the enforcement inherited from the file.
+func (r *admissionRule) validate(defaultEnforcement string) error {
+	if r.Name == "" {
+		return fmt.Errorf("every admission rule needs a name")
+	}
+	if r.Enforcement == "" {
+		r.Enforcement = defaultEnforcement
+	}
+	if err := validateEnforcement(r.Enforcement); err != nil {
+		return fmt.Errorf("rule %s: %w", r.Name, err)
+	}
+	if r.MaxDurationSeconds < 0 {
+		return fmt.Errorf("rule %s: maxDurationSeconds must not be negative", r.Name)
+	}
+	for _, pattern := range append(append([]string{}, r.DeniedFaults...), r.AllowedFaults...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("rule %s: invalid fault pattern %q", r.Name, pattern)
+		}
+	}
+	return nil
+}
+
+func validateEnforcement(enforcement string) error {
+	if enforcement != enforcementWarn && enforcement != enforcementDeny {
+		return fmt.Errorf("enforcement must be %s or %s, got %q", enforcementWarn, enforcementDeny, enforcement)
+	}
+	return nil
+}
+
+// matchesFault reports whether a fault name matches one of the glob patterns, such as node-*.
+func matchesFault(patterns []string, fault string) (string, bool) {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, fault); ok {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
+// evaluate returns the findings of the rule for every fault of the manifest.
+func (r *admissionRule) evaluate(manifest *experimentManifest) []policyFinding {
+	var findings []policyFinding
+	add := func(fault *manifestFault, check, value, limit, message string) {
+		findings = append(findings, policyFinding{
+			Rule:        r.Name,
+			Enforcement: r.Enforcement,
+			Check:       check,
+			Fault:       fault.Fault,
+			Step:        fault.StepName,
+			Value:       value,
+			Limit:       limit,
+			Message:     message,
+		})
+	}
+
+	for i := range manifest.Faults {
+		fault := &manifest.Faults[i]
+
+		if pattern, ok := matchesFault(r.DeniedFaults, fault.Fault); ok {
+			add(fault, "deniedFaults", fault.Fault, pattern, fmt.Sprintf("fault %s matches denied pattern %s", fault.Fault, pattern))
+		}
+		if len(r.AllowedFaults) > 0 {
+			if _, ok := matchesFault(r.AllowedFaults, fault.Fault); !ok {
+				add(fault, "allowedFaults", fault.Fault, strings.Join(r.AllowedFaults, ","), fmt.Sprintf("fault %s is not in the allowed faults", fault.Fault))
+			}
+		}
+		if r.MaxDurationSeconds > 0 {
+			seconds, ok := fault.durationSeconds()
+			switch {
+			case !ok:
+				add(fault, "maxDurationSeconds", fault.Env["TOTAL_CHAOS_DURATION"], fmt.Sprint(r.MaxDurationSeconds), fmt.Sprintf("fault %s has no numeric TOTAL_CHAOS_DURATION, so its duration cannot be checked", fault.Fault))
+			case seconds > r.MaxDurationSeconds:
+				add(fault, "maxDurationSeconds", fmt.Sprint(seconds), fmt.Sprint(r.MaxDurationSeconds), fmt.Sprintf("fault %s runs for %ds, longer than the %ds allowed", fault.Fault, seconds, r.MaxDurationSeconds))
+			}
+		}
+		if len(r.AllowedNamespaces) > 0 {
+			switch {
+			case fault.AppNamespace == "":
+				add(fault, "allowedNamespaces", "", strings.Join(r.AllowedNamespaces, ","), fmt.Sprintf("fault %s has no target namespace, so its namespace cannot be checked", fault.Fault))
+			case !containsFold(r.AllowedNamespaces, fault.AppNamespace):
+				add(fault, "allowedNamespaces", fault.AppNamespace, strings.Join(r.AllowedNamespaces, ","), fmt.Sprintf("fault %s targets namespace %s, which is not allowed", fault.Fault, fault.AppNamespace))
+			}
+		}
+		if r.RequireProbes && len(fault.Probes) == 0 {
+			add(fault, "requireProbes", "", "", fmt.Sprintf("fault %s has no resilience probe", fault.Fault))
+		}
+	}
+	return findings
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// admissionPolicy holds the rules from LITMUS_ADMISSION_POLICY_FILE, reloading the file when it changes.
+type admissionPolicy struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	rules   []admissionRule
+}
+
+// newAdmissionPolicy loads the policy, returning nil if no file is configured.
+func newAdmissionPolicy(path string) (*admissionPolicy, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	p := &admissionPolicy{path: path}
+	if err := p.reload(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *admissionPolicy) reload() error {
+	content, modTime, err := readIfModified(p.path, p.modTime)
+	if err != nil || content == nil {
+		return err
+	}
+
+	var file admissionPolicyFile
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return fmt.Errorf("failed to parse admission policy %s: %w", p.path, err)
+	}
+	if file.Enforcement == "" {
+		file.Enforcement = enforcementDeny
+	}
+	if err := validateEnforcement(file.Enforcement); err != nil {
+		return fmt.Errorf("admission policy %s: %w", p.path, err)
+	}
+	for i := range file.Rules {
+		if err := file.Rules[i].validate(file.Enforcement); err != nil {
+			return fmt.Errorf("admission policy %s: %w", p.path, err)
+		}
+	}
+
+	p.rules = file.Rules
+	p.modTime = modTime
+	log.Printf("Loaded %d admission rules from %s", len(p.rules), p.path)
+	return nil
+}
+
+// evaluate returns the findings of every rule whose scope covers the infrastructure.
+func (p *admissionPolicy) evaluate(manifest *experimentManifest, env *Environment, infraTags []string) []policyFinding {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Keep the last good policy if the file was broken by an edit
+	if err := p.reload(); err != nil {
+		log.Printf("Keeping previous admission policy: %v", err)
+	}
+
+	findings := []policyFinding{}
+	for i := range p.rules {
+		if p.rules[i].appliesTo(env, infraTags) {
+			findings = append(findings, p.rules[i].evaluate(manifest)...)
+		}
+	}
+	return findings
+}
+
+// denied reports whether any finding is enforced by denying the experiment.
+func denied(findings []policyFinding) bool {
+	for _, finding := range findings {
+		if finding.Enforcement == enforcementDeny {
+			return true
+		}
+	}
+	return false
+}
+
+// admitManifest checks an experiment manifest against the admission policy. It returns the findings to report
+// alongside the result, or a denial to return instead of going ahead. action describes what was attempted,
+// such as "running" or "creating".
+func (s *LitmusChaosServer) admitManifest(manifestText, experimentName, action string, infra *Infra, env *Environment) ([]policyFinding, *ToolResult, error) {
+	if s.admission == nil {
+		return nil, nil, nil
+	}
+
+	manifest, err := parseExperimentManifest(manifestText)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot check experiment %s against the admission policy: %w", experimentName, err)
+	}
+
+	var tags []string
+	if infra != nil {
+		tags = infra.Tags
+	}
+	findings := s.admission.evaluate(manifest, env, tags)
+	if len(findings) == 0 {
+		return nil, nil, nil
+	}
+	if !denied(findings) {
+		return findings, nil, nil
+	}
+
+	response := map[string]interface{}{
+		"status":     admissionDeniedStatus,
+		"message":    fmt.Sprintf("The admission policy does not allow %s experiment %s. Change the faults named in the findings and try again.", action, experimentName),
+		"experiment": experimentName,
+		"findings":   findings,
+	}
+
+	responseJSON, _ := json.MarshalIndent(response, "", "  ")
+
+	return nil, &ToolResult{
+		Content: []ContentItem{
+			{
+				Type: "text",
+				Text: string(responseJSON),
+			},
+		},
+		IsError: true,
+	}, nil
+}
@@ -0,0 +1,366 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults for the audit log
+const (
+	defaultAuditLogMaxMB    = 10
+	defaultAuditLogMaxFiles = 5
+	auditWebhookQueueSize   = 100
+	auditWebhookTimeout     = 10 * time.Second
+	maxAuditStringLength    = 2048
+	redactedValue           = "[REDACTED]"
+)
+
+// Argument names whose values never reach the audit log
+var sensitiveArgument = regexp.MustCompile(`(?i)token|password|secret|authorization|credential|api[-_]?key`)
+
+// Operation name of a GraphQL document, such as RunChaosExperiment in "mutation RunChaosExperiment(...)"
+var graphqlOperationName = regexp.MustCompile(`^\s*(query|mutation|subscription)\s+([A-Za-z_][A-Za-z0-9_]*)`)
+
+// clientInfo is what a client reported about itself in initialize.
+type clientInfo struct {
+	Name            string `json:"name,omitempty"`
+	Version         string `json:"version,omitempty"`
+	ProtocolVersion string `json:"protocolVersion,omitempty"`
+}
+
+// auditEntry is one line of the audit log.
+type auditEntry struct {
+	Timestamp  string                 `json:"timestamp"`
+	SessionID  string                 `json:"sessionId,omitempty"`
+	Client     *clientInfo            `json:"client,omitempty"`
+	Tool       string                 `json:"tool"`
+	ProjectID  string                 `json:"projectId"`
+	Arguments  map[string]interface{} `json:"arguments,omitempty"`
+	Operations []string               `json:"operations,omitempty"`
+	Outcome    string                 `json:"outcome"`
+	Error      string                 `json:"error,omitempty"`
+	ResultIDs  map[string]string      `json:"resultIds,omitempty"`
+	DurationMs int64                  `json:"durationMs"`
+}
+
+// Outcomes recorded in the audit log
+const (
+	auditOutcomeSuccess   = "success"
+	auditOutcomeError     = "error"
+	auditOutcomeBlocked   = "blocked"
+	auditOutcomeConfirmed = "confirmation_required"
+)
+
+// auditLog appends entries for mutating tool calls to a JSON-lines file that rotates by size,
+// and optionally mirrors them to a webhook.
+type auditLog struct {
+	path     string
+	maxBytes int64
+	maxFiles int
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+
+	webhookURL   string
+	webhookToken string
+	webhook      chan []byte
+	httpClient   *http.Client
+}
+
+// newAuditLog opens the audit log configured in config, returning nil if auditing is disabled.
+func newAuditLog(config *LitmusConfig) (*auditLog, error) {
+	if config.AuditLogPath == "" && config.AuditWebhookURL == "" {
+		return nil, nil
+	}
+
+	a := &auditLog{
+		path:         config.AuditLogPath,
+		maxBytes:     int64(config.AuditLogMaxMB) * 1024 * 1024,
+		maxFiles:     config.AuditLogMaxFiles,
+		webhookURL:   config.AuditWebhookURL,
+		webhookToken: config.AuditWebhookToken,
+	}
+
+	if a.path != "" {
+		if err := a.open(); err != nil {
+			return nil, err
+		}
+	}
+
+	if a.webhookURL != "" {
+		a.webhook = make(chan []byte, auditWebhookQueueSize)
+		a.httpClient = &http.Client{Timeout: auditWebhookTimeout}
+		go a.deliver()
+	}
+
+	return a, nil
+}
+
+func (a *auditLog) open() error {
+	file, err := os.OpenFile(a.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat audit log: %w", err)
+	}
+	a.file = file
+	a.size = info.Size()
+	return nil
+}
+
+// rotate renames the current file to path.1, shifting older files up and dropping the oldest.
+func (a *auditLog) rotate() error {
+	a.file.Close()
+	a.file = nil
+
+	os.Remove(fmt.Sprintf("%s.%d", a.path, a.maxFiles))
+	for i := a.maxFiles - 1; i >= 1; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", a.path, i), fmt.Sprintf("%s.%d", a.path, i+1))
+	}
+	if a.maxFiles > 0 {
+		if err := os.Rename(a.path, a.path+".1"); err != nil {
+			return fmt.Errorf("failed to rotate audit log: %w", err)
+		}
+	} else if err := os.Remove(a.path); err != nil {
+		return fmt.Errorf("failed to rotate audit log: %w", err)
+	}
+	return a.open()
+}
+
+// write appends an entry to the file and queues it for the webhook.
+func (a *auditLog) write(entry *auditEntry) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		log.Printf("Failed to marshal audit entry: %v", err)
+		return
+	}
+
+	if a.path != "" {
+		a.mu.Lock()
+		if a.file != nil && a.maxBytes > 0 && a.size > 0 && a.size+int64(len(line))+1 > a.maxBytes {
+			if err := a.rotate(); err != nil {
+				log.Printf("Audit log: %v", err)
+			}
+		}
+		if a.file == nil {
+			if err := a.open(); err != nil {
+				log.Printf("Audit log: %v", err)
+			}
+		}
+		if a.file != nil {
+			n, err := a.file.Write(append(line, '\n'))
+			a.size += int64(n)
+			if err != nil {
+				log.Printf("Failed to write audit entry: %v", err)
+			}
+		}
+		a.mu.Unlock()
+	}
+
+	if a.webhook != nil {
+		select {
+		case a.webhook <- line:
+		default:
+			log.Printf("Audit webhook queue is full, dropping entry for %s", entry.Tool)
+		}
+	}
+}
+
+// deliver posts queued entries to the webhook one at a time.
+func (a *auditLog) deliver() {
+	for line := range a.webhook {
+		req, err := http.NewRequest("POST", a.webhookURL, bytes.NewReader(line))
+		if err != nil {
+			log.Printf("Audit webhook: %v", err)
+			continue
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if a.webhookToken != "" {
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", a.webhookToken))
+		}
+
+		resp, err := a.httpClient.Do(req)
+		if err != nil {
+			log.Printf("Audit webhook delivery failed: %v", err)
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			log.Printf("Audit webhook delivery failed: %s", resp.Status)
+		}
+	}
+}
+
+// auditRecorder collects the GraphQL mutations sent while a tool call runs.
+type auditRecorder struct {
+	mu         sync.Mutex
+	operations []string
+}
+
+type auditRecorderKey struct{}
+
+// recordOperation notes a mutation sent on behalf of the audited tool call in ctx, if any.
+func recordOperation(ctx context.Context, query string) {
+	recorder, ok := ctx.Value(auditRecorderKey{}).(*auditRecorder)
+	if !ok || !isMutation(query) {
+		return
+	}
+
+	name := "anonymous mutation"
+	if match := graphqlOperationName.FindStringSubmatch(query); match != nil {
+		name = match[2]
+	}
+
+	recorder.mu.Lock()
+	recorder.operations = append(recorder.operations, name)
+	recorder.mu.Unlock()
+}
+
+// auditCall runs a mutating tool call and writes its audit entry. Calls that do not change anything are run as is.
+func (s *LitmusChaosServer) auditCall(ctx context.Context, toolName string, args map[string]interface{}, call func(context.Context) (*ToolResult, error)) (*ToolResult, error) {
+	if s.audit == nil || !isMutatingCall(toolName, args) {
+		return call(ctx)
+	}
+
+	start := time.Now()
+	recorder := &auditRecorder{}
+	result, err := call(context.WithValue(ctx, auditRecorderKey{}, recorder))
+
+	entry := &auditEntry{
+		Timestamp:  start.UTC().Format(time.RFC3339Nano),
+		Tool:       toolName,
+		ProjectID:  s.projectFromContext(ctx).ID,
+		Arguments:  redactArguments(args),
+		Operations: recorder.operations,
+		Outcome:    auditOutcomeSuccess,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if session := sessionFromContext(ctx); session != nil {
+		entry.SessionID = session.id
+		entry.Client = s.clientInfo(session.id)
+	}
+
+	switch {
+	case errorCode(err) == codeToolNotAllowed, errors.Is(err, errBlackout):
+		entry.Outcome = auditOutcomeBlocked
+		entry.Error = err.Error()
+	case err != nil:
+		entry.Outcome = auditOutcomeError
+		entry.Error = err.Error()
+	case result != nil && len(result.Content) > 0:
+		var response map[string]interface{}
+		if json.Unmarshal([]byte(result.Content[0].Text), &response) == nil {
+			switch response["status"] {
+			case auditOutcomeConfirmed:
+				entry.Outcome = auditOutcomeConfirmed
+			case admissionDeniedStatus:
+				entry.Outcome = auditOutcomeBlocked
+			}
+			entry.ResultIDs = collectIDs(response, "", 0)
+		}
+	}
+
+	s.audit.write(entry)
+	return result, err
+}
+
+// redactArguments copies tool arguments, replacing secrets and shortening long values such as manifests.
+func redactArguments(args map[string]interface{}) map[string]interface{} {
+	if args == nil {
+		return nil
+	}
+	redacted, _ := redactValue(args).(map[string]interface{})
+	return redacted
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			if sensitiveArgument.MatchString(key) {
+				copied[key] = redactedValue
+			} else {
+				copied[key] = redactValue(item)
+			}
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = redactValue(item)
+		}
+		return copied
+	case string:
+		if len(v) > maxAuditStringLength {
+			return fmt.Sprintf("%s... (%d bytes)", v[:maxAuditStringLength], len(v))
+		}
+		return v
+	}
+	return value
+}
+
+// collectIDs gathers the identifiers in a tool response, keyed by their path, such as environment.id or notifyId.
+func collectIDs(response map[string]interface{}, prefix string, depth int) map[string]string {
+	ids := map[string]string{}
+	if depth > 3 {
+		return ids
+	}
+
+	for key, value := range response {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case string:
+			if v != "" && (key == "id" || strings.HasSuffix(key, "Id") || strings.HasSuffix(key, "ID")) && !sensitiveArgument.MatchString(key) {
+				ids[path] = v
+			}
+		case map[string]interface{}:
+			for nestedPath, id := range collectIDs(v, path, depth+1) {
+				ids[nestedPath] = id
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	return ids
+}
+
+// rememberClient stores the client info a session reported in initialize.
+func (s *LitmusChaosServer) rememberClient(sessionID string, info clientInfo) {
+	s.clientsMu.Lock()
+	defer s.clientsMu.Unlock()
+	s.clients[sessionID] = &info
+}
+
+func (s *LitmusChaosServer) clientInfo(sessionID string) *clientInfo {
+	s.clientsMu.Lock()
+	defer s.clientsMu.Unlock()
+	return s.clients[sessionID]
+}
+
+// endSession releases everything the server keeps for a client session that has gone away.
+func (s *LitmusChaosServer) endSession(sessionID string) {
+	s.subscriptions.removeSession(sessionID)
+
+	s.clientsMu.Lock()
+	delete(s.clients, sessionID)
+	s.clientsMu.Unlock()
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Tokens are refreshed this long before they expire, or halfway through their lifetime if that is shorter
+const tokenRefreshMargin = 5 * time.Minute
+
+// loginCredentials are the username and password sent to the auth server's /login endpoint.
+type loginCredentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// loginResponse is the body the auth server returns for a successful login.
+type loginResponse struct {
+	AccessToken string `json:"accessToken"`
+	ProjectID   string `json:"projectID"`
+	ExpiresIn   int64  `json:"expiresIn"`
+	Type        string `json:"type"`
+}
+
+// tokenSource supplies the bearer token for Chaos Center requests. With a username and password, or a credentials
+// file, it logs in through the auth server, caches the JWT and logs in again shortly before the JWT expires.
+// Otherwise it hands out a static access token.
+type tokenSource struct {
+	config     *LitmusConfig
+	httpClient *http.Client
+	static     string
+
+	mu        sync.Mutex
+	token     string
+	refreshAt time.Time
+}
+
+func newTokenSource(config *LitmusConfig, httpClient *http.Client) *tokenSource {
+	return &tokenSource{
+		config:     config,
+		httpClient: httpClient,
+		static:     config.AccessToken,
+	}
+}
+
+// newStaticTokenSource returns a source that always hands out the given access token.
+func newStaticTokenSource(token string) *tokenSource {
+	return &tokenSource{static: token}
+}
+
+// canLogin reports whether the source can obtain a fresh token, which makes retrying rejected requests worthwhile.
+func (t *tokenSource) canLogin() bool {
+	return t.config != nil && (t.config.Username != "" || t.config.CredentialsFile != "")
+}
+
+// get returns a token that is not about to expire, logging in first if necessary.
+func (t *tokenSource) get(ctx context.Context) (string, error) {
+	if !t.canLogin() {
+		return t.static, nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != "" && (t.refreshAt.IsZero() || time.Now().Before(t.refreshAt)) {
+		return t.token, nil
+	}
+
+	token, expiresAt, err := t.login(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	t.token = token
+	t.refreshAt = time.Time{}
+	if !expiresAt.IsZero() {
+		margin := tokenRefreshMargin
+		if lifetime := time.Until(expiresAt); lifetime/2 < margin {
+			margin = lifetime / 2
+		}
+		t.refreshAt = expiresAt.Add(-margin)
+		log.Printf("Logged in to Chaos Center as %s, token expires at %s", t.username(), expiresAt.Format(time.RFC3339))
+	} else {
+		log.Printf("Logged in to Chaos Center as %s", t.username())
+	}
+	return token, nil
+}
+
+// invalidate discards a token that Chaos Center rejected, unless another request has already replaced it.
+func (t *tokenSource) invalidate(token string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token == token {
+		t.token = ""
+	}
+}
+
+func (t *tokenSource) username() string {
+	if t.config.Username != "" {
+		return t.config.Username
+	}
+	return "user from " + t.config.CredentialsFile
+}
+
+// currentUsername returns the user the current token belongs to, if it can be told.
+func (t *tokenSource) currentUsername(ctx context.Context) string {
+	if t.canLogin() && t.config.Username != "" {
+		return t.config.Username
+	}
+	token, err := t.get(ctx)
+	if err != nil {
+		return ""
+	}
+	return parseJWTClaims(token).Username
+}
+
+// credentials returns the configured username and password. The credentials file is read on every login,
+// so rotated passwords are picked up without a restart.
+func (t *tokenSource) credentials() (*loginCredentials, error) {
+	if t.config.Username != "" {
+		return &loginCredentials{Username: t.config.Username, Password: t.config.Password}, nil
+	}
+
+	content, err := os.ReadFile(t.config.CredentialsFile)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read credentials file: %w", errAuthFailed, err)
+	}
+
+	var creds loginCredentials
+	if err := yaml.Unmarshal(content, &creds); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse credentials file %s: %v", errAuthFailed, t.config.CredentialsFile, err)
+	}
+	if creds.Username == "" || creds.Password == "" {
+		return nil, fmt.Errorf("%w: credentials file %s must set username and password", errAuthFailed, t.config.CredentialsFile)
+	}
+	return &creds, nil
+}
+
+// login exchanges the credentials for a JWT and reports when it expires, if known.
+func (t *tokenSource) login(ctx context.Context) (string, time.Time, error) {
+	creds, err := t.credentials()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	jsonBody, err := json.Marshal(creds)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to marshal login request: %w", err)
+	}
+
+	url := strings.TrimRight(t.config.AuthEndpoint, "/") + "/login"
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to create login request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("%w: failed to log in: %w", errUpstream, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("%w: failed to read login response: %w", errUpstream, err)
+	}
+
+	switch {
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests:
+		return "", time.Time{}, fmt.Errorf("%w: login failed: %w", errUpstream, newHTTPStatusError(resp, body))
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return "", time.Time{}, fmt.Errorf("%w: login as %s rejected: %v", errAuthFailed, creds.Username, newHTTPStatusError(resp, body))
+	}
+
+	var login loginResponse
+	if err := json.Unmarshal(body, &login); err != nil {
+		return "", time.Time{}, fmt.Errorf("%w: failed to unmarshal login response: %v", errUpstream, err)
+	}
+	if login.AccessToken == "" {
+		return "", time.Time{}, fmt.Errorf("%w: login response has no access token", errUpstream)
+	}
+
+	expiresAt := jwtExpiry(login.AccessToken)
+	if expiresAt.IsZero() && login.ExpiresIn > 0 {
+		expiresAt = time.Now().Add(time.Duration(login.ExpiresIn) * time.Second)
+	}
+	return login.AccessToken, expiresAt, nil
+}
+
+// jwtClaims are the claims of a Chaos Center JWT that the server uses.
+type jwtClaims struct {
+	Exp      int64  `json:"exp"`
+	Username string `json:"username"`
+}
+
+// parseJWTClaims reads the claims of a JWT without verifying it, returning empty claims for anything else.
+func parseJWTClaims(token string) jwtClaims {
+	var claims jwtClaims
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return claims
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return claims
+	}
+	json.Unmarshal(payload, &claims)
+	return claims
+}
+
+// jwtExpiry reads the exp claim of a JWT, returning the zero time if there is none.
+func jwtExpiry(token string) time.Time {
+	if claims := parseJWTClaims(token); claims.Exp != 0 {
+		return time.Unix(claims.Exp, 0)
+	}
+	return time.Time{}
+}
+
+// defaultAuthEndpoint derives the auth server URL from the GraphQL endpoint, following the Chaos Center
+// frontend's routing of /api to the GraphQL server and /auth to the auth server.
+func defaultAuthEndpoint(chaosCenterEndpoint string) string {
+	endpoint := strings.TrimRight(chaosCenterEndpoint, "/")
+	return strings.TrimSuffix(endpoint, "/api") + "/auth"
+}
+
+// authenticatedAttempt calls send with the current token of auth. If Chaos Center rejects a token the server
+// obtained by logging in, the token is discarded and send is called once more with a fresh one;
+// a rejected request was not executed, so this is safe for mutations too.
+func authenticatedAttempt(ctx context.Context, auth *tokenSource, send func(token string) (json.RawMessage, error)) (json.RawMessage, error) {
+	token, err := auth.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := send(token)
+	if err == nil || !errors.Is(err, errAuthFailed) || !auth.canLogin() {
+		return data, err
+	}
+
+	log.Printf("Chaos Center rejected the access token, logging in again: %v", err)
+	auth.invalidate(token)
+	if token, err = auth.get(ctx); err != nil {
+		return nil, err
+	}
+	return send(token)
+}
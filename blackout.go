@@ -0,0 +1,480 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// errBlackout marks runs and schedules rejected because a blackout window is active.
+var errBlackout = errors.New("chaos blackout window active")
+
+// How far ahead the calendar looks for the end of a blackout or the start of the next one
+const blackoutSearchHorizon = 366 * 24 * time.Hour
+
+// blackoutFile is the YAML or JSON file named by LITMUS_BLACKOUT_FILE.
+type blackoutFile struct {
+	Timezone string           `json:"timezone"`
+	Windows  []blackoutWindow `json:"windows"`
+}
+
+// targetScope selects infrastructure by its environment or tags. An empty scope selects all infrastructure.
+type targetScope struct {
+	Environments []string `json:"environments,omitempty"`
+	InfraTags    []string `json:"infraTags,omitempty"`
+}
+
+// appliesTo reports whether the scope covers infrastructure in env with the given tags.
+// Environments match by name, ID or type.
+func (s *targetScope) appliesTo(env *Environment, infraTags []string) bool {
+	if len(s.Environments) == 0 && len(s.InfraTags) == 0 {
+		return true
+	}
+	if env != nil {
+		for _, scope := range s.Environments {
+			if strings.EqualFold(scope, env.Name) || scope == env.EnvironmentID || strings.EqualFold(scope, env.Type) {
+				return true
+			}
+		}
+	}
+	for _, scope := range s.InfraTags {
+		for _, tag := range infraTags {
+			if strings.EqualFold(scope, tag) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// readIfModified reads a file that is reloaded when it changes. It returns nil content if the file's
+// modification time is still since.
+func readIfModified(path string, since time.Time) ([]byte, time.Time, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, since, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if info.ModTime().Equal(since) {
+		return nil, since, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, since, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return content, info.ModTime(), nil
+}
+
+// blackoutWindow is a period in which chaos must not run. It either recurs, starting whenever Cron matches and
+// lasting Duration, or covers the explicit range from Start to End. Without a scope it applies everywhere.
+type blackoutWindow struct {
+	Name     string `json:"name"`
+	Reason   string `json:"reason"`
+	Cron     string `json:"cron,omitempty"`
+	Duration string `json:"duration,omitempty"`
+	Start    string `json:"start,omitempty"`
+	End      string `json:"end,omitempty"`
+	Timezone string `json:"timezone,omitempty"`
+	targetScope
+
+	schedule *cronSchedule
+	duration time.Duration
+	start    time.Time
+	end      time.Time
+}
+
+// compile validates the window and parses its times in the window's or the file's time zone.
+func (w *blackoutWindow) compile(defaultLocation *time.Location) error {
+	if w.Name == "" {
+		return fmt.Errorf("every blackout window needs a name")
+	}
+
+	location := defaultLocation
+	if w.Timezone != "" {
+		var err error
+		if location, err = time.LoadLocation(w.Timezone); err != nil {
+			return fmt.Errorf("window %s: invalid timezone: %w", w.Name, err)
+		}
+	}
+
+	switch {
+	case w.Cron != "" && (w.Start != "" || w.End != ""):
+		return fmt.Errorf("window %s: set either cron and duration or start and end", w.Name)
+	case w.Cron != "":
+		schedule, err := parseCron(w.Cron, location)
+		if err != nil {
+			return fmt.Errorf("window %s: %w", w.Name, err)
+		}
+		duration, err := time.ParseDuration(w.Duration)
+		if err != nil || duration <= 0 {
+			return fmt.Errorf("window %s: duration must be a positive duration such as 8h", w.Name)
+		}
+		w.schedule, w.duration = schedule, duration
+	case w.Start != "" && w.End != "":
+		start, err := parseWindowTime(w.Start, location, false)
+		if err != nil {
+			return fmt.Errorf("window %s: invalid start: %w", w.Name, err)
+		}
+		end, err := parseWindowTime(w.End, location, true)
+		if err != nil {
+			return fmt.Errorf("window %s: invalid end: %w", w.Name, err)
+		}
+		if !end.After(start) {
+			return fmt.Errorf("window %s: end must be after start", w.Name)
+		}
+		w.start, w.end = start, end
+	default:
+		return fmt.Errorf("window %s: set either cron and duration or start and end", w.Name)
+	}
+	return nil
+}
+
+// parseWindowTime accepts RFC 3339 timestamps, local times and dates. A date as the end of a range includes the whole day.
+func parseWindowTime(value string, location *time.Location, end bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02T15:04", value, location); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", value, location)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected RFC 3339 time, 2006-01-02T15:04 or 2006-01-02, got %q", value)
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+// activeUntil returns when the window ends if it is active at t, or the zero time otherwise.
+func (w *blackoutWindow) activeUntil(t time.Time) time.Time {
+	if w.schedule == nil {
+		if !t.Before(w.start) && t.Before(w.end) {
+			return w.end
+		}
+		return time.Time{}
+	}
+
+	// Every occurrence that started within one duration before t is still running
+	var until time.Time
+	for start := w.schedule.next(t.Add(-w.duration)); !start.IsZero() && !start.After(t); start = w.schedule.next(start) {
+		until = start.Add(w.duration)
+	}
+	return until
+}
+
+// nextStart returns the first start of the window after t, or the zero time if there is none.
+func (w *blackoutWindow) nextStart(t time.Time) time.Time {
+	if w.schedule == nil {
+		if w.start.After(t) {
+			return w.start
+		}
+		return time.Time{}
+	}
+	return w.schedule.next(t)
+}
+
+// blackoutError explains which window blocks chaos and when it may run again.
+type blackoutError struct {
+	Window       string
+	Reason       string
+	Until        time.Time
+	NextBlackout string
+	AllowedUntil time.Time
+}
+
+func (e *blackoutError) Error() string {
+	message := fmt.Sprintf("blackout window %q is active", e.Window)
+	if e.Reason != "" {
+		message += ": " + e.Reason
+	}
+	if e.Until.IsZero() {
+		return message + "; no allowed window within the next year"
+	}
+	message += fmt.Sprintf("; chaos is allowed again from %s", e.Until.Format(time.RFC3339))
+	if !e.AllowedUntil.IsZero() {
+		message += fmt.Sprintf(" until %s, when blackout window %q starts", e.AllowedUntil.Format(time.RFC3339), e.NextBlackout)
+	}
+	return message
+}
+
+func (e *blackoutError) Unwrap() error {
+	return errBlackout
+}
+
+// blackoutCalendar holds the blackout windows from LITMUS_BLACKOUT_FILE, reloading the file when it changes.
+type blackoutCalendar struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	windows []blackoutWindow
+}
+
+// newBlackoutCalendar loads the calendar, returning nil if no file is configured.
+func newBlackoutCalendar(path string) (*blackoutCalendar, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	c := &blackoutCalendar{path: path}
+	if err := c.reload(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *blackoutCalendar) reload() error {
+	content, modTime, err := readIfModified(c.path, c.modTime)
+	if err != nil || content == nil {
+		return err
+	}
+
+	var file blackoutFile
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return fmt.Errorf("failed to parse blackout file %s: %w", c.path, err)
+	}
+
+	location := time.UTC
+	if file.Timezone != "" {
+		if location, err = time.LoadLocation(file.Timezone); err != nil {
+			return fmt.Errorf("blackout file %s: invalid timezone: %w", c.path, err)
+		}
+	}
+	for i := range file.Windows {
+		if err := file.Windows[i].compile(location); err != nil {
+			return fmt.Errorf("blackout file %s: %w", c.path, err)
+		}
+	}
+
+	c.windows = file.Windows
+	c.modTime = modTime
+	log.Printf("Loaded %d blackout windows from %s", len(c.windows), c.path)
+	return nil
+}
+
+// check returns a blackoutError if a window covering the infrastructure is active at t.
+func (c *blackoutCalendar) check(env *Environment, infraTags []string, t time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Keep the last good calendar if the file was broken by an edit
+	if err := c.reload(); err != nil {
+		log.Printf("Keeping previous blackout windows: %v", err)
+	}
+
+	var windows []*blackoutWindow
+	for i := range c.windows {
+		if c.windows[i].appliesTo(env, infraTags) {
+			windows = append(windows, &c.windows[i])
+		}
+	}
+
+	var blocking *blackoutWindow
+	for _, w := range windows {
+		if !w.activeUntil(t).IsZero() {
+			blocking = w
+			break
+		}
+	}
+	if blocking == nil {
+		return nil
+	}
+
+	blackout := &blackoutError{Window: blocking.Name, Reason: blocking.Reason}
+
+	// Overlapping and back-to-back windows extend the blackout
+	until := t
+	for until.Before(t.Add(blackoutSearchHorizon)) {
+		extended := until
+		for _, w := range windows {
+			if end := w.activeUntil(until); end.After(extended) {
+				extended = end
+			}
+		}
+		if extended.Equal(until) {
+			break
+		}
+		until = extended
+	}
+	if !until.Before(t.Add(blackoutSearchHorizon)) {
+		return blackout
+	}
+	blackout.Until = until
+
+	for _, w := range windows {
+		if start := w.nextStart(until); !start.IsZero() && (blackout.AllowedUntil.IsZero() || start.Before(blackout.AllowedUntil)) {
+			blackout.AllowedUntil = start
+			blackout.NextBlackout = w.Name
+		}
+	}
+	return blackout
+}
+
+// checkBlackout returns a blackoutError if chaos must not run on infra at the moment. env is the infrastructure's
+// environment, or nil if it has none.
+func (s *LitmusChaosServer) checkBlackout(infra *Infra, env *Environment) error {
+	if s.blackouts == nil {
+		return nil
+	}
+
+	var tags []string
+	if infra != nil {
+		tags = infra.Tags
+	}
+	return s.blackouts.check(env, tags, time.Now())
+}
+
+// infraEnvironment fetches the environment of an infrastructure, returning nil if it has none.
+func (s *LitmusChaosServer) infraEnvironment(ctx context.Context, infra *Infra) (*Environment, error) {
+	if infra == nil || infra.EnvironmentID == "" {
+		return nil, nil
+	}
+	return s.fetchEnvironment(ctx, infra.EnvironmentID)
+}
+
+// cronSchedule is a parsed five-field cron expression: minute, hour, day of month, month and day of week.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domStar, dowStar              bool
+	location                      *time.Location
+}
+
+var cronMonthNames = map[string]int{"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6, "jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12}
+var cronDayNames = map[string]int{"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6}
+
+func parseCron(expr string, location *time.Location) (*cronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron %q must have five fields: minute hour day-of-month month day-of-week", expr)
+	}
+
+	s := &cronSchedule{location: location}
+	var err error
+	if s.minute, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("cron %q minute: %w", expr, err)
+	}
+	if s.hour, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("cron %q hour: %w", expr, err)
+	}
+	if s.dom, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("cron %q day of month: %w", expr, err)
+	}
+	if s.month, err = parseCronField(fields[3], 1, 12, cronMonthNames); err != nil {
+		return nil, fmt.Errorf("cron %q month: %w", expr, err)
+	}
+	if s.dow, err = parseCronField(fields[4], 0, 7, cronDayNames); err != nil {
+		return nil, fmt.Errorf("cron %q day of week: %w", expr, err)
+	}
+	// 7 is another name for Sunday
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domStar = fields[2] == "*"
+	s.dowStar = fields[4] == "*"
+	return s, nil
+}
+
+// parseCronField parses a comma-separated list of values, ranges and steps into a bit set.
+func parseCronField(field string, min, max int, names map[string]int) (uint64, error) {
+	value := func(s string) (int, error) {
+		if n, ok := names[strings.ToLower(s)]; ok {
+			return n, nil
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil || n < min || n > max {
+			return 0, fmt.Errorf("%q is not between %d and %d", s, min, max)
+		}
+		return n, nil
+	}
+
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q", stepPart)
+			}
+			step = n
+		}
+
+		low, high := min, max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			from, to, _ := strings.Cut(rangePart, "-")
+			var err error
+			if low, err = value(from); err != nil {
+				return 0, err
+			}
+			if high, err = value(to); err != nil {
+				return 0, err
+			}
+			if high < low {
+				return 0, fmt.Errorf("invalid range %q", rangePart)
+			}
+		default:
+			n, err := value(rangePart)
+			if err != nil {
+				return 0, err
+			}
+			low = n
+			if !hasStep {
+				high = n
+			}
+		}
+
+		for n := low; n <= high; n += step {
+			bits |= 1 << uint(n)
+		}
+	}
+	return bits, nil
+}
+
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	// As in cron, a day matches either field when both are restricted
+	if !s.domStar && !s.dowStar {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
+
+// next returns the first time after t that matches the schedule, or the zero time if there is none within the search horizon.
+func (s *cronSchedule) next(t time.Time) time.Time {
+	t = t.In(s.location).Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(blackoutSearchHorizon)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.location)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, s.location)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
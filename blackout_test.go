@@ -0,0 +1,300 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func bits(values ...int) uint64 {
+	var b uint64
+	for _, v := range values {
+		b |= 1 << uint(v)
+	}
+	return b
+}
+
+func TestParseCronField(t *testing.T) {
+	tests := []struct {
+		field    string
+		min, max int
+		names    map[string]int
+		want     uint64
+		wantErr  bool
+	}{
+		{field: "*", min: 0, max: 5, want: bits(0, 1, 2, 3, 4, 5)},
+		{field: "7", min: 0, max: 59, want: bits(7)},
+		{field: "1,3,5", min: 0, max: 59, want: bits(1, 3, 5)},
+		{field: "2-4", min: 0, max: 59, want: bits(2, 3, 4)},
+		{field: "*/15", min: 0, max: 59, want: bits(0, 15, 30, 45)},
+		{field: "10-30/10", min: 0, max: 59, want: bits(10, 20, 30)},
+		{field: "5/20", min: 0, max: 59, want: bits(5, 25, 45)},
+		{field: "1-2,20-22/2", min: 0, max: 23, want: bits(1, 2, 20, 22)},
+		{field: "*/5", min: 1, max: 12, want: bits(1, 6, 11)},
+		{field: "mon-fri", min: 0, max: 7, names: cronDayNames, want: bits(1, 2, 3, 4, 5)},
+		{field: "SUN,sat", min: 0, max: 7, names: cronDayNames, want: bits(0, 6)},
+		{field: "jan,jun-aug", min: 1, max: 12, names: cronMonthNames, want: bits(1, 6, 7, 8)},
+		{field: "60", min: 0, max: 59, wantErr: true},
+		{field: "0", min: 1, max: 31, wantErr: true},
+		{field: "5-1", min: 0, max: 59, wantErr: true},
+		{field: "*/0", min: 0, max: 59, wantErr: true},
+		{field: "*/x", min: 0, max: 59, wantErr: true},
+		{field: "1-", min: 0, max: 59, wantErr: true},
+		{field: "", min: 0, max: 59, wantErr: true},
+		{field: "mon", min: 0, max: 59, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			got, err := parseCronField(tt.field, tt.min, tt.max, tt.names)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseCronField(%q) = %b, want error", tt.field, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseCronField(%q): %v", tt.field, err)
+			}
+			if got != tt.want {
+				t.Errorf("parseCronField(%q) = %b, want %b", tt.field, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCron(t *testing.T) {
+	s, err := parseCron("0 9 * * 7", time.UTC)
+	if err != nil {
+		t.Fatalf("parseCron: %v", err)
+	}
+	if s.dow != bits(0, 7) {
+		t.Errorf("day of week 7 = %b, want Sunday (bit 0) set", s.dow)
+	}
+	if !s.domStar || s.dowStar {
+		t.Errorf("domStar, dowStar = %v, %v, want true, false", s.domStar, s.dowStar)
+	}
+
+	for _, expr := range []string{"", "0 9 * *", "0 9 * * * *", "60 * * * *", "* 24 * * *", "* * 32 * *", "* * * 13 *", "* * * * 8"} {
+		if _, err := parseCron(expr, time.UTC); err == nil {
+			t.Errorf("parseCron(%q) succeeded, want error", expr)
+		}
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		expr     string
+		location *time.Location
+		from     string
+		want     string
+	}{
+		{name: "next minute of a step", expr: "*/15 * * * *", location: time.UTC, from: "2026-10-16T10:07:30Z", want: "2026-10-16T10:15:00Z"},
+		{name: "strictly after a match", expr: "0 9 * * *", location: time.UTC, from: "2026-10-16T09:00:00Z", want: "2026-10-17T09:00:00Z"},
+		{name: "weekday range skips the weekend", expr: "0 9 * * mon-fri", location: time.UTC, from: "2026-10-16T10:00:00Z", want: "2026-10-19T09:00:00Z"},
+		{name: "7 is Sunday", expr: "0 0 * * 7", location: time.UTC, from: "2026-10-17T12:00:00Z", want: "2026-10-18T00:00:00Z"},
+		{name: "month name rolls into the next year", expr: "0 0 1 jan *", location: time.UTC, from: "2026-10-17T12:00:00Z", want: "2027-01-01T00:00:00Z"},
+		{name: "day of month skips short months", expr: "0 0 31 * *", location: time.UTC, from: "2026-04-01T00:00:00Z", want: "2026-05-31T00:00:00Z"},
+		{name: "day of month and weekday match either, weekday first", expr: "0 0 13 * fri", location: time.UTC, from: "2026-10-01T00:00:00Z", want: "2026-10-02T00:00:00Z"},
+		{name: "day of month and weekday match either, day of month first", expr: "0 0 13 * fri", location: time.UTC, from: "2026-10-10T00:00:00Z", want: "2026-10-13T00:00:00Z"},
+		{name: "restricted day of month with any weekday", expr: "0 0 13 * *", location: time.UTC, from: "2026-10-01T00:00:00Z", want: "2026-10-13T00:00:00Z"},
+		{name: "restricted weekday with any day of month", expr: "0 0 * * tue", location: time.UTC, from: "2026-10-01T00:00:00Z", want: "2026-10-06T00:00:00Z"},
+		{name: "no match within the horizon", expr: "0 0 30 feb *", location: time.UTC, from: "2026-10-17T00:00:00Z", want: ""},
+		{name: "evaluated in the schedule's time zone", expr: "0 9 * * *", location: berlin, from: "2026-10-16T08:00:00Z", want: "2026-10-17T07:00:00Z"},
+		{name: "spring forward: hour after the gap", expr: "0 3 * * *", location: berlin, from: "2026-03-28T23:00:00Z", want: "2026-03-29T01:00:00Z"},
+		{name: "spring forward: step continues after the gap", expr: "*/30 * * * *", location: berlin, from: "2026-03-29T00:45:00Z", want: "2026-03-29T01:00:00Z"},
+		{name: "spring forward: time in the gap is skipped", expr: "30 2 * * *", location: berlin, from: "2026-03-28T23:00:00Z", want: "2026-03-30T00:30:00Z"},
+		{name: "fall back: step continues into the repeated hour", expr: "*/30 * * * *", location: berlin, from: "2026-10-25T00:45:00Z", want: "2026-10-25T01:00:00Z"},
+		{name: "fall back: hour after the repeated hour", expr: "0 4 * * *", location: berlin, from: "2026-10-24T22:00:00Z", want: "2026-10-25T03:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := parseCron(tt.expr, tt.location)
+			if err != nil {
+				t.Fatalf("parseCron(%q): %v", tt.expr, err)
+			}
+			from, err := time.Parse(time.RFC3339, tt.from)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := s.next(from)
+			if tt.want == "" {
+				if !got.IsZero() {
+					t.Errorf("next(%s) = %s, want none", tt.from, got.UTC().Format(time.RFC3339))
+				}
+				return
+			}
+			want, err := time.Parse(time.RFC3339, tt.want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(want) {
+				t.Errorf("next(%s) = %s, want %s", tt.from, got.UTC().Format(time.RFC3339), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWindowTime(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		value   string
+		end     bool
+		want    string
+		wantErr bool
+	}{
+		{value: "2026-12-24T18:00:00Z", want: "2026-12-24T18:00:00Z"},
+		{value: "2026-12-24T18:00:00Z", end: true, want: "2026-12-24T18:00:00Z"},
+		{value: "2026-12-24T18:00", want: "2026-12-24T17:00:00Z"},
+		{value: "2026-12-24T18:00", end: true, want: "2026-12-24T17:00:00Z"},
+		{value: "2026-12-24", want: "2026-12-23T23:00:00Z"},
+		{value: "2026-12-26", end: true, want: "2026-12-26T23:00:00Z"},
+		// The day the clocks change is 23 hours long
+		{value: "2026-03-29", end: true, want: "2026-03-29T22:00:00Z"},
+		{value: "24.12.2026", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseWindowTime(tt.value, berlin, tt.end)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseWindowTime(%q) = %s, want error", tt.value, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseWindowTime(%q): %v", tt.value, err)
+			continue
+		}
+		if want, _ := time.Parse(time.RFC3339, tt.want); !got.Equal(want) {
+			t.Errorf("parseWindowTime(%q, end=%v) = %s, want %s", tt.value, tt.end, got.UTC().Format(time.RFC3339), tt.want)
+		}
+	}
+}
+
+func TestBlackoutCalendarCheck(t *testing.T) {
+	calendar := `
+timezone: Europe/Berlin
+windows:
+  - name: nightly-batch
+    reason: batch jobs
+    cron: "0 22 * * *"
+    duration: 10h
+    environments: [PROD]
+  - name: morning-release
+    start: 2026-11-03T08:00
+    end: 2026-11-03T12:00
+    environments: [PROD]
+  - name: holidays
+    reason: code freeze
+    start: 2026-12-20
+    end: 2026-12-24T12:00
+  - name: christmas
+    start: 2026-12-24T10:00
+    end: 2026-12-26
+  - name: edge-maintenance
+    start: 2026-11-10
+    end: 2026-11-10
+    infraTags: [edge]
+`
+	path := filepath.Join(t.TempDir(), "blackouts.yaml")
+	if err := os.WriteFile(path, []byte(calendar), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	c, err := newBlackoutCalendar(path)
+	if err != nil {
+		t.Fatalf("newBlackoutCalendar: %v", err)
+	}
+
+	prod := &Environment{EnvironmentID: "env-1", Name: "production", Type: "PROD"}
+	staging := &Environment{EnvironmentID: "env-2", Name: "staging", Type: "NON_PROD"}
+
+	tests := []struct {
+		name         string
+		env          *Environment
+		tags         []string
+		at           string
+		window       string
+		until        string
+		allowedUntil string
+		nextBlackout string
+	}{
+		{name: "outside every window", env: prod, at: "2026-11-02T12:00:00Z"},
+		{name: "recurring window across midnight", env: prod, at: "2026-11-02T02:00:00Z", window: "nightly-batch", until: "2026-11-02T07:00:00Z", allowedUntil: "2026-11-02T21:00:00Z", nextBlackout: "nightly-batch"},
+		{name: "recurring window from its start", env: prod, at: "2026-11-01T21:00:00Z", window: "nightly-batch", until: "2026-11-02T07:00:00Z", allowedUntil: "2026-11-02T21:00:00Z", nextBlackout: "nightly-batch"},
+		{name: "back-to-back windows extend until", env: prod, at: "2026-11-03T05:00:00Z", window: "nightly-batch", until: "2026-11-03T11:00:00Z", allowedUntil: "2026-11-03T21:00:00Z", nextBlackout: "nightly-batch"},
+		{name: "scope limits recurring window", env: staging, at: "2026-11-02T02:00:00Z"},
+		{name: "overlapping windows extend until", env: staging, at: "2026-12-21T12:00:00Z", window: "holidays", until: "2026-12-26T23:00:00Z"},
+		{name: "date-only end covers the whole day", env: staging, at: "2026-12-26T22:59:00Z", window: "christmas", until: "2026-12-26T23:00:00Z"},
+		{name: "date-only end is exclusive of the next day", env: staging, at: "2026-12-26T23:00:00Z"},
+		{name: "infra tag selects window", env: staging, tags: []string{"Edge"}, at: "2026-11-10T12:00:00Z", window: "edge-maintenance", until: "2026-11-10T23:00:00Z", allowedUntil: "2026-12-19T23:00:00Z", nextBlackout: "holidays"},
+		{name: "other infra tags are not covered", env: staging, tags: []string{"core"}, at: "2026-11-10T12:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at, _ := time.Parse(time.RFC3339, tt.at)
+			err := c.check(tt.env, tt.tags, at)
+			if tt.window == "" {
+				if err != nil {
+					t.Fatalf("check at %s: %v, want no blackout", tt.at, err)
+				}
+				return
+			}
+
+			var blackout *blackoutError
+			if !errors.As(err, &blackout) || !errors.Is(err, errBlackout) {
+				t.Fatalf("check at %s = %v, want a blackout", tt.at, err)
+			}
+			if blackout.Window != tt.window {
+				t.Errorf("window = %q, want %q", blackout.Window, tt.window)
+			}
+			if got := blackout.Until.UTC().Format(time.RFC3339); got != tt.until {
+				t.Errorf("until = %s, want %s", got, tt.until)
+			}
+			allowedUntil := ""
+			if !blackout.AllowedUntil.IsZero() {
+				allowedUntil = blackout.AllowedUntil.UTC().Format(time.RFC3339)
+			}
+			if allowedUntil != tt.allowedUntil || blackout.NextBlackout != tt.nextBlackout {
+				t.Errorf("allowed until %q (%q), want %q (%q)", allowedUntil, blackout.NextBlackout, tt.allowedUntil, tt.nextBlackout)
+			}
+		})
+	}
+}
+
+func TestBlackoutWindowCompileErrors(t *testing.T) {
+	tests := []blackoutWindow{
+		{Cron: "0 22 * * *", Duration: "8h"},
+		{Name: "both", Cron: "0 22 * * *", Duration: "8h", Start: "2026-12-24"},
+		{Name: "no duration", Cron: "0 22 * * *"},
+		{Name: "negative duration", Cron: "0 22 * * *", Duration: "-1h"},
+		{Name: "bad cron", Cron: "0 22 * *", Duration: "8h"},
+		{Name: "open range", Start: "2026-12-24"},
+		{Name: "reversed range", Start: "2026-12-24", End: "2026-12-23"},
+		{Name: "empty range", Start: "2026-12-24T10:00", End: "2026-12-24T10:00"},
+		{Name: "bad timezone", Start: "2026-12-24", End: "2026-12-25", Timezone: "Mars/Olympus"},
+	}
+
+	for _, w := range tests {
+		w := w
+		if err := w.compile(time.UTC); err == nil {
+			t.Errorf("compile(%+v) succeeded, want error", w)
+		}
+	}
+}
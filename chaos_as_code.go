@@ -0,0 +1,907 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Kinds of documents in a spec directory
+const (
+	specKindEnvironment = "Environment"
+	specKindProbe       = "Probe"
+	specKindExperiment  = "Experiment"
+)
+
+// Actions in a plan
+const (
+	planCreate    = "create"
+	planUpdate    = "update"
+	planUnchanged = "unchanged"
+)
+
+// Page size used to read every experiment of a project
+const planExperimentPageSize = 100
+
+// environmentSpec declares an environment. Its ID is derived from the name, as create_environment does.
+type environmentSpec struct {
+	Name        string   `json:"name"`
+	Type        string   `json:"type"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+}
+
+func (e *environmentSpec) environmentID() string {
+	return strings.ToLower(strings.ReplaceAll(e.Name, " ", "-"))
+}
+
+// probeSpec declares a resilience probe. Properties take the same keys as in create_resilience_probe.
+type probeSpec struct {
+	Name        string                 `json:"name"`
+	Type        string                 `json:"type"`
+	Description string                 `json:"description,omitempty"`
+	Tags        []string               `json:"tags,omitempty"`
+	Properties  map[string]interface{} `json:"properties"`
+}
+
+// experimentSpec declares an experiment. Manifest is the path, relative to the spec file, of the experiment's
+// YAML as written by export_chaos_experiment.
+type experimentSpec struct {
+	Name        string   `json:"name"`
+	InfraID     string   `json:"infraId"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Manifest    string   `json:"manifest"`
+
+	manifest map[string]interface{}
+}
+
+// chaosSpec is everything declared in a spec directory.
+type chaosSpec struct {
+	Dir          string
+	Environments []environmentSpec
+	Probes       []probeSpec
+	Experiments  []experimentSpec
+}
+
+// loadChaosSpec reads every YAML file below dir. Each document declares an Environment, Probe or Experiment;
+// Workflow, CronWorkflow and ChaosEngine documents are the experiment manifests those refer to and are skipped.
+func loadChaosSpec(dir string) (*chaosSpec, error) {
+	spec := &chaosSpec{Dir: dir}
+	seen := map[string]string{}
+
+	realRoot, err := realPath(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec directory: %w", err)
+	}
+
+	err = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || (filepath.Ext(path) != ".yaml" && filepath.Ext(path) != ".yml") {
+			return nil
+		}
+
+		content, err := readSpecFile(realRoot, path)
+		if err != nil {
+			return err
+		}
+
+		for i, doc := range splitYAMLDocuments(string(content)) {
+			var header struct {
+				Kind string `json:"kind"`
+				Name string `json:"name"`
+			}
+			if err := yaml.Unmarshal([]byte(doc), &header); err != nil {
+				return fmt.Errorf("%s, document %d: %w", path, i+1, err)
+			}
+
+			var target interface{}
+			switch header.Kind {
+			case "Workflow", "CronWorkflow", "ChaosEngine":
+				continue
+			case specKindEnvironment:
+				spec.Environments = append(spec.Environments, environmentSpec{})
+				target = &spec.Environments[len(spec.Environments)-1]
+			case specKindProbe:
+				spec.Probes = append(spec.Probes, probeSpec{})
+				target = &spec.Probes[len(spec.Probes)-1]
+			case specKindExperiment:
+				spec.Experiments = append(spec.Experiments, experimentSpec{})
+				target = &spec.Experiments[len(spec.Experiments)-1]
+			default:
+				return fmt.Errorf("%s, document %d: expected kind Environment, Probe or Experiment, got %q", path, i+1, header.Kind)
+			}
+
+			if header.Name == "" {
+				return fmt.Errorf("%s, document %d: %s has no name", path, i+1, header.Kind)
+			}
+			key := header.Kind + "/" + header.Name
+			if previous, ok := seen[key]; ok {
+				return fmt.Errorf("%s %s is declared in both %s and %s", header.Kind, header.Name, previous, path)
+			}
+			seen[key] = path
+
+			if err := yaml.Unmarshal([]byte(doc), target); err != nil {
+				return fmt.Errorf("%s, document %d: %w", path, i+1, err)
+			}
+			if exp, ok := target.(*experimentSpec); ok {
+				if err := exp.loadManifest(dir, realRoot, filepath.Dir(path)); err != nil {
+					return fmt.Errorf("%s, experiment %s: %w", path, exp.Name, err)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec directory: %w", err)
+	}
+
+	for _, env := range spec.Environments {
+		if env.Type == "" {
+			return nil, fmt.Errorf("environment %s has no type (PROD or NON_PROD)", env.Name)
+		}
+	}
+	for _, probe := range spec.Probes {
+		if probe.Type == "" || probe.Properties == nil {
+			return nil, fmt.Errorf("probe %s needs a type and properties", probe.Name)
+		}
+	}
+	return spec, nil
+}
+
+// loadManifest reads the manifest of an experiment declared in a spec file in dir. The manifest must lie inside
+// the spec directory root, whose symlinks resolve to realRoot.
+func (e *experimentSpec) loadManifest(root, realRoot, dir string) error {
+	if e.InfraID == "" {
+		return fmt.Errorf("infraId is required")
+	}
+	if e.Manifest == "" {
+		return fmt.Errorf("manifest is required")
+	}
+
+	path := filepath.Join(dir, e.Manifest)
+	if filepath.IsAbs(e.Manifest) || !withinDir(root, path) {
+		return fmt.Errorf("manifest %s is outside the spec directory", e.Manifest)
+	}
+
+	content, err := readSpecFile(realRoot, path)
+	if err != nil {
+		return err
+	}
+	if e.manifest, err = importExperimentYAML(string(content)); err != nil {
+		return fmt.Errorf("invalid manifest %s: %w", e.Manifest, err)
+	}
+	return nil
+}
+
+// planChange is what apply would do to one resource.
+type planChange struct {
+	Kind   string      `json:"kind"`
+	Name   string      `json:"name"`
+	ID     string      `json:"id,omitempty"`
+	Action string      `json:"action"`
+	Diff   []fieldDiff `json:"diff,omitempty"`
+	Error  string      `json:"error,omitempty"`
+
+	apply func(ctx context.Context) error
+}
+
+// fieldDiff is a field whose live value differs from the spec.
+type fieldDiff struct {
+	Field string      `json:"field"`
+	Live  interface{} `json:"live"`
+	Spec  interface{} `json:"spec"`
+}
+
+// chaosPlan compares a spec directory with the live state of a project.
+type chaosPlan struct {
+	SpecDir   string            `json:"specDir"`
+	Summary   map[string]int    `json:"summary"`
+	Changes   []*planChange     `json:"changes"`
+	Unmanaged []unmanagedObject `json:"unmanaged"`
+
+	// confirmationToken confirms the schedule on PROD infrastructure whose preview stopped the last apply
+	confirmationToken string
+}
+
+// unmanagedObject is a live resource that no spec declares. Apply leaves it alone.
+type unmanagedObject struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	ID   string `json:"id,omitempty"`
+}
+
+func (p *chaosPlan) add(change *planChange) {
+	if change.Action == planUpdate && len(change.Diff) == 0 {
+		change.Action = planUnchanged
+	}
+	p.Changes = append(p.Changes, change)
+	p.Summary[change.Action]++
+}
+
+// pending reports whether applying the plan would change anything.
+func (p *chaosPlan) pending() bool {
+	return p.Summary[planCreate]+p.Summary[planUpdate] > 0
+}
+
+// diffField records a difference between a live and a declared value.
+func diffField(diffs []fieldDiff, field string, live, spec interface{}) []fieldDiff {
+	if !reflect.DeepEqual(live, spec) {
+		diffs = append(diffs, fieldDiff{Field: field, Live: live, Spec: spec})
+	}
+	return diffs
+}
+
+// sortedTags returns tags in a stable order for comparison, treating nil and empty alike.
+func sortedTags(tags []string) []string {
+	sorted := append([]string{}, tags...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+// planChaosSpec diffs the spec against the environments, probes and experiments of the project in ctx.
+func (s *LitmusChaosServer) planChaosSpec(ctx context.Context, spec *chaosSpec) (*chaosPlan, error) {
+	plan := &chaosPlan{
+		SpecDir:   spec.Dir,
+		Summary:   map[string]int{planCreate: 0, planUpdate: 0, planUnchanged: 0},
+		Changes:   []*planChange{},
+		Unmanaged: []unmanagedObject{},
+	}
+
+	if err := s.planEnvironments(ctx, spec, plan); err != nil {
+		return nil, err
+	}
+	if err := s.planProbes(ctx, spec, plan); err != nil {
+		return nil, err
+	}
+	if err := s.planExperiments(ctx, spec, plan); err != nil {
+		return nil, err
+	}
+	plan.Summary["unmanaged"] = len(plan.Unmanaged)
+	return plan, nil
+}
+
+func (s *LitmusChaosServer) planEnvironments(ctx context.Context, spec *chaosSpec, plan *chaosPlan) error {
+	var listEnvs ListEnvironmentResponse
+	if err := s.query(ctx, listEnvironmentsQuery, map[string]interface{}{}, "listEnvironments", &listEnvs); err != nil {
+		return fmt.Errorf("failed to list environments: %w", err)
+	}
+
+	live := map[string]*Environment{}
+	for i := range listEnvs.Environments {
+		live[listEnvs.Environments[i].EnvironmentID] = &listEnvs.Environments[i]
+	}
+
+	declared := map[string]bool{}
+	for i := range spec.Environments {
+		env := spec.Environments[i]
+		id := env.environmentID()
+		declared[id] = true
+
+		current, ok := live[id]
+		if !ok {
+			plan.add(&planChange{Kind: specKindEnvironment, Name: env.Name, ID: id, Action: planCreate, apply: func(ctx context.Context) error {
+				_, err := s.createEnvironment(ctx, map[string]interface{}{
+					"name":        env.Name,
+					"type":        env.Type,
+					"description": env.Description,
+					"tags":        stringsToInterfaces(env.Tags),
+				})
+				return err
+			}})
+			continue
+		}
+
+		var diffs []fieldDiff
+		diffs = diffField(diffs, "name", current.Name, env.Name)
+		diffs = diffField(diffs, "type", current.Type, env.Type)
+		diffs = diffField(diffs, "description", current.Description, env.Description)
+		diffs = diffField(diffs, "tags", sortedTags(current.Tags), sortedTags(env.Tags))
+		plan.add(&planChange{Kind: specKindEnvironment, Name: env.Name, ID: id, Action: planUpdate, Diff: diffs, apply: func(ctx context.Context) error {
+			return s.updateEnvironment(ctx, id, &env)
+		}})
+	}
+
+	for _, env := range listEnvs.Environments {
+		if !declared[env.EnvironmentID] {
+			plan.Unmanaged = append(plan.Unmanaged, unmanagedObject{Kind: specKindEnvironment, Name: env.Name, ID: env.EnvironmentID})
+		}
+	}
+	return nil
+}
+
+func (s *LitmusChaosServer) updateEnvironment(ctx context.Context, id string, env *environmentSpec) error {
+	mutation := `
+		mutation UpdateEnvironment($projectID: ID!, $request: UpdateEnvironmentRequest) {
+			updateEnvironment(projectID: $projectID, request: $request)
+		}
+	`
+
+	tags := env.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	variables := map[string]interface{}{
+		"request": map[string]interface{}{
+			"environmentID": id,
+			"name":          env.Name,
+			"type":          env.Type,
+			"description":   env.Description,
+			"tags":          tags,
+		},
+	}
+
+	var result string
+	return s.query(ctx, mutation, variables, "updateEnvironment", &result)
+}
+
+func (s *LitmusChaosServer) planProbes(ctx context.Context, spec *chaosSpec, plan *chaosPlan) error {
+	var probes ProbeList
+	if err := s.query(ctx, listProbesQuery, map[string]interface{}{}, "listProbes", &probes); err != nil {
+		return fmt.Errorf("failed to list resilience probes: %w", err)
+	}
+
+	live := map[string]*Probe{}
+	for i := range probes {
+		live[probes[i].Name] = &probes[i]
+	}
+
+	declared := map[string]bool{}
+	for i := range spec.Probes {
+		probe := spec.Probes[i]
+		declared[probe.Name] = true
+
+		current, ok := live[probe.Name]
+		if !ok {
+			plan.add(&planChange{Kind: specKindProbe, Name: probe.Name, Action: planCreate, apply: func(ctx context.Context) error {
+				_, err := s.createResilienceProbe(ctx, map[string]interface{}{
+					"name":        probe.Name,
+					"type":        probe.Type,
+					"description": probe.Description,
+					"tags":        stringsToInterfaces(probe.Tags),
+					"properties":  probe.Properties,
+				})
+				return err
+			}})
+			continue
+		}
+
+		// listProbes does not return properties, so they come from getProbe
+		details, err := s.fetchProbeDetails(ctx, probe.Name)
+		if err != nil {
+			return err
+		}
+
+		var diffs []fieldDiff
+		diffs = diffField(diffs, "type", current.Type, probe.Type)
+		diffs = diffField(diffs, "description", current.Description, probe.Description)
+		diffs = diffField(diffs, "tags", sortedTags(current.Tags), sortedTags(probe.Tags))
+		diffs = append(diffs, diffProbeProperties(details, probeRequest(probe.Name, probe.Type, probe.Description, nil, probe.Properties))...)
+		plan.add(&planChange{Kind: specKindProbe, Name: probe.Name, Action: planUpdate, Diff: diffs, apply: func(ctx context.Context) error {
+			return s.updateProbe(ctx, probeRequest(probe.Name, probe.Type, probe.Description, sortedTags(probe.Tags), probe.Properties))
+		}})
+	}
+
+	for _, probe := range probes {
+		if !declared[probe.Name] {
+			plan.Unmanaged = append(plan.Unmanaged, unmanagedObject{Kind: specKindProbe, Name: probe.Name})
+		}
+	}
+	return nil
+}
+
+// getProbeQuery fetches a probe with the properties of every probe type; only those of its own type are set.
+const getProbeQuery = `
+		query GetProbe($projectID: ID!, $probeName: ID!) {
+			getProbe(projectID: $projectID, probeName: $probeName) {
+				name
+				type
+				kubernetesHTTPProperties {
+					probeTimeout
+					interval
+					retry
+					attempt
+					url
+					method {
+						get {
+							criteria
+							responseCode
+						}
+						post {
+							contentType
+							body
+							criteria
+							responseCode
+						}
+					}
+					insecureSkipVerify
+				}
+				kubernetesCMDProperties {
+					probeTimeout
+					interval
+					retry
+					attempt
+					command
+					comparator {
+						type
+						value
+						criteria
+					}
+				}
+				k8sProperties {
+					probeTimeout
+					interval
+					retry
+					attempt
+					group
+					version
+					resource
+					operation
+				}
+				promProperties {
+					probeTimeout
+					interval
+					retry
+					attempt
+					endpoint
+					query
+					comparator {
+						type
+						value
+						criteria
+					}
+				}
+			}
+		}
+	`
+
+// probePropertyFields hold the properties of each probe type in getProbe and in ProbeRequest.
+var probePropertyFields = []string{"kubernetesHTTPProperties", "kubernetesCMDProperties", "k8sProperties", "promProperties"}
+
+// fetchProbeDetails returns a probe as getProbe sends it, properties included.
+func (s *LitmusChaosServer) fetchProbeDetails(ctx context.Context, name string) (map[string]interface{}, error) {
+	variables := map[string]interface{}{
+		"probeName": name,
+	}
+
+	var details map[string]interface{}
+	if err := s.query(ctx, getProbeQuery, variables, "getProbe", &details); err != nil {
+		return nil, fmt.Errorf("failed to fetch resilience probe %s: %w", name, err)
+	}
+	return details, nil
+}
+
+// diffProbeProperties compares the properties apply would send for a probe with the live ones, field by field.
+// Only fields the request sets are compared, so defaults Chaos Center fills in do not show up as changes.
+func diffProbeProperties(live, request map[string]interface{}) []fieldDiff {
+	var diffs []fieldDiff
+	for _, field := range probePropertyFields {
+		want, ok := normalizeProperties(request[field]).(map[string]interface{})
+		if !ok {
+			continue
+		}
+		have, _ := normalizeProperties(live[field]).(map[string]interface{})
+		for _, key := range sortedMapKeys(want) {
+			diffs = diffField(diffs, "properties."+key, have[key], want[key])
+		}
+	}
+	return diffs
+}
+
+// normalizeProperties round-trips a value through JSON, so numbers compare alike, and drops null fields, which
+// getProbe returns for everything that is unset.
+func normalizeProperties(value interface{}) interface{} {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	var normalized interface{}
+	if json.Unmarshal(data, &normalized) != nil {
+		return nil
+	}
+	return dropNulls(normalized)
+}
+
+func dropNulls(value interface{}) interface{} {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+	for key, field := range object {
+		if field == nil {
+			delete(object, key)
+			continue
+		}
+		object[key] = dropNulls(field)
+	}
+	return object
+}
+
+func sortedMapKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (s *LitmusChaosServer) updateProbe(ctx context.Context, request map[string]interface{}) error {
+	mutation := `
+		mutation UpdateProbe($request: ProbeRequest!, $projectID: ID!) {
+			updateProbe(request: $request, projectID: $projectID)
+		}
+	`
+
+	variables := map[string]interface{}{
+		"request": request,
+	}
+
+	var result string
+	return s.query(ctx, mutation, variables, "updateProbe", &result)
+}
+
+// listAllExperiments pages through listExperiment and returns every experiment of the project.
+func (s *LitmusChaosServer) listAllExperiments(ctx context.Context) ([]Experiment, error) {
+	var experiments []Experiment
+	for page := 0; ; page++ {
+		variables := map[string]interface{}{
+			"request": map[string]interface{}{
+				"pagination": map[string]interface{}{
+					"page":  page,
+					"limit": planExperimentPageSize,
+				},
+			},
+		}
+
+		var listExperiment ListExperimentResponse
+		if err := s.query(ctx, listExperimentsQuery, variables, "listExperiment", &listExperiment); err != nil {
+			return nil, fmt.Errorf("failed to list experiments: %w", err)
+		}
+		experiments = append(experiments, listExperiment.Experiments...)
+		if len(listExperiment.Experiments) < planExperimentPageSize || len(experiments) >= listExperiment.TotalNoOfExperiments {
+			return experiments, nil
+		}
+	}
+}
+
+func (s *LitmusChaosServer) planExperiments(ctx context.Context, spec *chaosSpec, plan *chaosPlan) error {
+	experiments, err := s.listAllExperiments(ctx)
+	if err != nil {
+		return err
+	}
+
+	live := map[string]*Experiment{}
+	for i := range experiments {
+		live[experiments[i].Name] = &experiments[i]
+	}
+
+	infras := map[string]*Infra{}
+	declared := map[string]bool{}
+	for i := range spec.Experiments {
+		exp := spec.Experiments[i]
+		name := sanitizeResourceName(exp.Name)
+		declared[name] = true
+
+		infra, ok := infras[exp.InfraID]
+		if !ok {
+			if infra, err = s.fetchInfra(ctx, exp.InfraID); err != nil {
+				return fmt.Errorf("experiment %s: failed to fetch infrastructure %s: %w", exp.Name, exp.InfraID, err)
+			}
+			infras[exp.InfraID] = infra
+		}
+
+		draft := &experimentDraft{
+			ID:          newUUID(),
+			Name:        name,
+			Description: exp.Description,
+			Tags:        sortedTags(exp.Tags),
+			Infra:       infra,
+			Manifest:    exp.manifest,
+			IsCustom:    true,
+			Scheduling:  scheduleChange(nil, exp.manifest, infra),
+		}
+
+		current, ok := live[name]
+		if !ok {
+			retargetManifest(draft.Manifest, draft.ID, name, infra)
+			plan.add(&planChange{Kind: specKindExperiment, Name: name, ID: draft.ID, Action: planCreate, apply: func(ctx context.Context) error {
+				return s.applyExperiment(ctx, draft, false, plan.confirmationToken)
+			}})
+			continue
+		}
+
+		getExperiment, err := s.fetchExperiment(ctx, current.ExperimentID)
+		if err != nil {
+			return err
+		}
+		details := getExperiment.ExperimentDetails
+
+		draft.ID = details.ExperimentID
+		draft.Weightages = details.Weightages
+		draft.IsCustom = details.IsCustomExperiment
+		draft.Scheduling = scheduleChange(details, exp.manifest, infra)
+		retargetManifest(draft.Manifest, draft.ID, name, infra)
+
+		liveInfra := ""
+		if details.Infra != nil {
+			liveInfra = details.Infra.InfraID
+		}
+
+		var diffs []fieldDiff
+		diffs = diffField(diffs, "infraId", liveInfra, exp.InfraID)
+		diffs = diffField(diffs, "description", details.Description, exp.Description)
+		diffs = diffField(diffs, "tags", sortedTags(details.Tags), sortedTags(exp.Tags))
+		diffs = diffField(diffs, "schedule", details.CronSyntax, manifestSchedule(exp.manifest))
+
+		liveYAML, err := exportExperimentYAML(details.ExperimentManifest)
+		if err != nil {
+			return fmt.Errorf("experiment %s: %w", name, err)
+		}
+		specJSON, _ := json.Marshal(exp.manifest)
+		specYAML, err := exportExperimentYAML(string(specJSON))
+		if err != nil {
+			return fmt.Errorf("experiment %s: %w", name, err)
+		}
+		if liveYAML != specYAML {
+			diffs = append(diffs, fieldDiff{Field: "manifest", Live: "differs", Spec: exp.Manifest})
+		}
+
+		plan.add(&planChange{Kind: specKindExperiment, Name: name, ID: draft.ID, Action: planUpdate, Diff: diffs, apply: func(ctx context.Context) error {
+			return s.applyExperiment(ctx, draft, true, plan.confirmationToken)
+		}})
+	}
+
+	for _, exp := range experiments {
+		if !declared[exp.Name] {
+			plan.Unmanaged = append(plan.Unmanaged, unmanagedObject{Kind: specKindExperiment, Name: exp.Name, ID: exp.ExperimentID})
+		}
+	}
+	return nil
+}
+
+// applyExperiment saves an experiment draft, turning an admission policy denial or PROD schedule preview into an
+// error.
+func (s *LitmusChaosServer) applyExperiment(ctx context.Context, draft *experimentDraft, update bool, confirmationToken string) error {
+	draft.ConfirmationToken = confirmationToken
+	_, denial, err := s.saveExperiment(ctx, draft, update, "applying")
+	if err != nil {
+		return err
+	}
+	if denial != nil {
+		return fmt.Errorf("%s", denial.Content[0].Text)
+	}
+	return nil
+}
+
+func stringsToInterfaces(values []string) []interface{} {
+	items := make([]interface{}, len(values))
+	for i, v := range values {
+		items[i] = v
+	}
+	return items
+}
+
+// resolveSpecDir returns the spec directory named by a tool argument, relative to LITMUS_SPEC_DIR. The tools only
+// read directories inside LITMUS_SPEC_DIR, so clients of a shared server cannot make it read the rest of the host;
+// the plan command, run by whoever runs the server, takes any path.
+func (s *LitmusChaosServer) resolveSpecDir(dir string) (string, error) {
+	root := s.config.SpecDir
+	if root == "" {
+		return "", fmt.Errorf("LITMUS_SPEC_DIR is not set; spec directories can only be read from inside it")
+	}
+	if filepath.IsAbs(dir) {
+		return "", fmt.Errorf("dir %s must be relative to LITMUS_SPEC_DIR", dir)
+	}
+
+	resolved := filepath.Join(root, dir)
+	if !withinDir(root, resolved) {
+		return "", fmt.Errorf("dir %s is outside LITMUS_SPEC_DIR", dir)
+	}
+
+	// A symlink inside the root may still point out of it
+	if realRoot, err := realPath(root); err == nil {
+		if real, err := realPath(resolved); err == nil && !withinDir(realRoot, real) {
+			return "", fmt.Errorf("dir %s is outside LITMUS_SPEC_DIR", dir)
+		}
+	}
+	return resolved, nil
+}
+
+// realPath returns the absolute path of a file or directory with every symlink resolved.
+func realPath(path string) (string, error) {
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Abs(resolved)
+}
+
+// readSpecFile reads a file of the spec directory whose symlinks resolve to realRoot. Files that are symlinks to
+// somewhere outside it are refused before they are read, so their content never reaches a client.
+func readSpecFile(realRoot, path string) ([]byte, error) {
+	real, err := realPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if !withinDir(realRoot, real) {
+		return nil, fmt.Errorf("%s links outside the spec directory", path)
+	}
+	return os.ReadFile(real)
+}
+
+// withinDir reports whether path is root or lies below it. Both must be clean or joined paths.
+func withinDir(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// planChaosAsCode diffs a spec directory against Chaos Center without changing anything.
+func (s *LitmusChaosServer) planChaosAsCode(ctx context.Context, args map[string]interface{}) (*ToolResult, error) {
+	dir, err := s.resolveSpecDir(getStringFromArgs(args, "dir", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := loadChaosSpec(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := s.planChaosSpec(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	responseJSON, _ := json.MarshalIndent(plan, "", "  ")
+
+	return &ToolResult{
+		Content: []ContentItem{
+			{
+				Type: "text",
+				Text: string(responseJSON),
+			},
+		},
+	}, nil
+}
+
+// applyChaosAsCode creates and updates resources until Chaos Center matches a spec directory. Resources that no
+// spec declares are left alone. It stops at the first failure, reporting what was applied until then.
+func (s *LitmusChaosServer) applyChaosAsCode(ctx context.Context, args map[string]interface{}) (*ToolResult, error) {
+	dir, err := s.resolveSpecDir(getStringFromArgs(args, "dir", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := loadChaosSpec(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := s.planChaosSpec(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	plan.confirmationToken = getStringFromArgs(args, "confirmationToken", "")
+
+	applied := 0
+	var failed *planChange
+	for _, change := range plan.Changes {
+		if change.Action == planUnchanged {
+			continue
+		}
+		if err := change.apply(ctx); err != nil {
+			change.Error = err.Error()
+			failed = change
+			break
+		}
+		applied++
+	}
+
+	response := map[string]interface{}{
+		"success": failed == nil,
+		"message": fmt.Sprintf("Applied %d of %d changes", applied, plan.Summary[planCreate]+plan.Summary[planUpdate]),
+		"plan":    plan,
+	}
+	if failed != nil {
+		response["message"] = fmt.Sprintf("Applied %d changes, then failed to %s %s %s: %s", applied, failed.Action, failed.Kind, failed.Name, failed.Error)
+	}
+
+	responseJSON, _ := json.MarshalIndent(response, "", "  ")
+
+	return &ToolResult{
+		Content: []ContentItem{
+			{
+				Type: "text",
+				Text: string(responseJSON),
+			},
+		},
+		IsError: failed != nil,
+	}, nil
+}
+
+// formatPlanText renders a plan for the terminal: + create, ~ update, and unmanaged resources.
+func formatPlanText(plan *chaosPlan) string {
+	var b strings.Builder
+	for _, change := range plan.Changes {
+		switch change.Action {
+		case planCreate:
+			fmt.Fprintf(&b, "+ %s %s\n", change.Kind, change.Name)
+		case planUpdate:
+			fmt.Fprintf(&b, "~ %s %s\n", change.Kind, change.Name)
+			for _, diff := range change.Diff {
+				fmt.Fprintf(&b, "    %s: %v -> %v\n", diff.Field, diff.Live, diff.Spec)
+			}
+		}
+	}
+	for _, object := range plan.Unmanaged {
+		fmt.Fprintf(&b, "? %s %s is not declared in the spec and will be left alone\n", object.Kind, object.Name)
+	}
+	fmt.Fprintf(&b, "\nPlan: %d to create, %d to update, %d unchanged, %d unmanaged.\n",
+		plan.Summary[planCreate], plan.Summary[planUpdate], plan.Summary[planUnchanged], plan.Summary["unmanaged"])
+	return b.String()
+}
+
+// runPlanCommand implements the plan subcommand: it prints the plan of a spec directory and returns the exit code,
+// 0 if Chaos Center matches the spec, 2 if applying it would change something and 1 on errors.
+func runPlanCommand(args []string) int {
+	flags := flag.NewFlagSet("plan", flag.ExitOnError)
+	project := flags.String("project", "", "Project name or ID to plan against (default: LITMUS_PROJECT_ID)")
+	asJSON := flags.Bool("json", false, "Print the plan as JSON")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s plan [-project name] [-json] [dir]\n", filepath.Base(os.Args[0]))
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	server := NewLitmusChaosServer()
+
+	dir := flags.Arg(0)
+	if dir == "" {
+		dir = server.config.SpecDir
+	}
+	if dir == "" {
+		dir = "."
+	}
+
+	ctx := context.Background()
+	if *project != "" {
+		ctx = withProject(ctx, server.resolveProject(*project))
+	}
+
+	spec, err := loadChaosSpec(dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	plan, err := server.planChaosSpec(ctx, spec)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if *asJSON {
+		planJSON, _ := json.MarshalIndent(plan, "", "  ")
+		fmt.Println(string(planJSON))
+	} else {
+		fmt.Print(formatPlanText(plan))
+	}
+
+	if plan.pending() {
+		return 2
+	}
+	return 0
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// JSON-RPC error codes. Codes from -32000 to -32099 are reserved for server-defined errors.
+const (
+	codeParseError       = -32700
+	codeMethodNotFound   = -32601
+	codeInvalidParams    = -32602
+	codeInternalError    = -32603
+	codeUnknownTool      = -32001
+	codeResourceNotFound = -32002
+	codeUpstreamError    = -32003
+	codeAuthFailed       = -32004
+	codeTimeout          = -32005
+	codeToolNotAllowed   = -32006
+)
+
+var (
+	// errInvalidParams marks errors caused by malformed or missing request parameters.
+	errInvalidParams = errors.New("invalid params")
+
+	// errUnknownTool is returned by tools/call for tool names the server does not provide.
+	errUnknownTool = errors.New("unknown tool")
+
+	// errUpstream marks failures talking to Chaos Center, such as connection errors or unreadable responses.
+	errUpstream = errors.New("Chaos Center request failed")
+
+	// errGraphQL marks errors reported by Chaos Center in the GraphQL errors array.
+	errGraphQL = errors.New("GraphQL errors")
+
+	// errAuthFailed marks requests that Chaos Center rejected because of missing or invalid credentials.
+	errAuthFailed = errors.New("authentication with Chaos Center failed")
+)
+
+// GraphQL error messages that Chaos Center uses for rejected credentials
+var authErrorMarkers = []string{"permission_denied", "invalid token", "unauthorized", "token is expired"}
+
+// isAuthMessage reports whether a GraphQL error message describes rejected credentials.
+func isAuthMessage(message string) bool {
+	message = strings.ToLower(message)
+	for _, marker := range authErrorMarkers {
+		if strings.Contains(message, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// isTimeout reports whether err was caused by a deadline or network timeout.
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// errorCode maps an error to the JSON-RPC error code reported to the client.
+func errorCode(err error) int {
+	switch {
+	case errors.Is(err, errInvalidParams):
+		return codeInvalidParams
+	case errors.Is(err, errUnknownTool):
+		return codeUnknownTool
+	case errors.Is(err, errToolNotAllowed):
+		return codeToolNotAllowed
+	case errors.Is(err, errResourceNotFound):
+		return codeResourceNotFound
+	case errors.Is(err, errAuthFailed):
+		return codeAuthFailed
+	case isTimeout(err):
+		return codeTimeout
+	case errors.Is(err, errUpstream), errors.Is(err, errGraphQL), errors.Is(err, errMalformedResponse):
+		return codeUpstreamError
+	default:
+		return codeInternalError
+	}
+}
+
+// rpcError converts a handler error into a JSON-RPC error.
+func rpcError(err error) *MCPError {
+	return &MCPError{
+		Code:    errorCode(err),
+		Message: err.Error(),
+	}
+}
+
+// isProtocolError reports whether a tools/call failure must be returned as a JSON-RPC error rather than a tool result:
+// the call itself was malformed, or the server cannot reach Chaos Center in a way the model cannot fix by changing its arguments.
+func isProtocolError(err error) bool {
+	switch errorCode(err) {
+	case codeInvalidParams, codeUnknownTool, codeToolNotAllowed, codeAuthFailed, codeTimeout:
+		return true
+	}
+	return false
+}
+
+// toolError reports a failed tool execution to the model as a tool result with isError set.
+func toolError(err error) *ToolResult {
+	return &ToolResult{
+		Content: []ContentItem{
+			{
+				Type: "text",
+				Text: fmt.Sprintf("Error: %v", err),
+			},
+		},
+		IsError: true,
+	}
+}
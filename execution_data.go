@@ -0,0 +1,347 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Node type of the workflow steps that run a fault
+const chaosEngineNodeType = "ChaosEngine"
+
+// executionData is the workflow status Chaos Center records for an experiment run, stored as JSON in executionData.
+type executionData struct {
+	Name       string                    `json:"name"`
+	Namespace  string                    `json:"namespace"`
+	Phase      string                    `json:"phase"`
+	Message    string                    `json:"message"`
+	StartedAt  string                    `json:"startedAt"`
+	FinishedAt string                    `json:"finishedAt"`
+	Nodes      map[string]*executionNode `json:"nodes"`
+}
+
+// executionNode is one node of the workflow: a step, a group of steps, or the workflow itself.
+type executionNode struct {
+	Name       string     `json:"name"`
+	Type       string     `json:"type"`
+	Phase      string     `json:"phase"`
+	Message    string     `json:"message"`
+	StartedAt  string     `json:"startedAt"`
+	FinishedAt string     `json:"finishedAt"`
+	Children   []string   `json:"children"`
+	ChaosData  *chaosData `json:"chaosData"`
+}
+
+// chaosData is what the subscriber reports about the ChaosEngine of a fault step.
+type chaosData struct {
+	EngineName             string       `json:"engineName"`
+	EngineUID              string       `json:"engineUID"`
+	Namespace              string       `json:"namespace"`
+	ExperimentName         string       `json:"experimentName"`
+	ExperimentStatus       string       `json:"experimentStatus"`
+	ExperimentVerdict      string       `json:"experimentVerdict"`
+	ExperimentPod          string       `json:"experimentPod"`
+	RunnerPod              string       `json:"runnerPod"`
+	ProbeSuccessPercentage string       `json:"probeSuccessPercentage"`
+	FailStep               string       `json:"failStep"`
+	LastUpdatedAt          string       `json:"lastUpdatedAt"`
+	ChaosResult            *chaosResult `json:"chaosResult"`
+}
+
+// chaosResult is the part of the ChaosResult resource of a fault that describes its probes and earlier runs.
+type chaosResult struct {
+	Status struct {
+		ProbeStatuses []probeStatus `json:"probeStatuses"`
+		History       *struct {
+			PassedRuns  int `json:"passedRuns"`
+			FailedRuns  int `json:"failedRuns"`
+			StoppedRuns int `json:"stoppedRuns"`
+		} `json:"history"`
+	} `json:"status"`
+}
+
+// probeStatus is the outcome of one resilience probe of a fault.
+type probeStatus struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Mode   string `json:"mode"`
+	Status struct {
+		Verdict     string `json:"verdict"`
+		Description string `json:"description"`
+	} `json:"status"`
+}
+
+// parseExecutionData decodes the executionData of an experiment run.
+func parseExecutionData(data string) (*executionData, error) {
+	if data == "" {
+		return nil, fmt.Errorf("the run has no execution data yet")
+	}
+
+	var execution executionData
+	if err := json.Unmarshal([]byte(data), &execution); err != nil {
+		return nil, fmt.Errorf("failed to parse execution data: %w", err)
+	}
+	return &execution, nil
+}
+
+// faultNodes returns the ChaosEngine nodes that carry chaos data, in the order they started.
+func (e *executionData) faultNodes() []*executionNode {
+	var nodes []*executionNode
+	for _, node := range e.Nodes {
+		if node.Type == chaosEngineNodeType && node.ChaosData != nil {
+			nodes = append(nodes, node)
+		}
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		a, _ := parseExecutionTime(nodes[i].StartedAt)
+		b, _ := parseExecutionTime(nodes[j].StartedAt)
+		if !a.Equal(b) {
+			return a.Before(b)
+		}
+		return nodes[i].Name < nodes[j].Name
+	})
+	return nodes
+}
+
+// parseExecutionTime reads the timestamps in execution data, which the subscriber writes as Unix seconds
+// and older versions as RFC 3339.
+func parseExecutionTime(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds <= 0 {
+			return time.Time{}, false
+		}
+		return time.Unix(seconds, 0).UTC(), true
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), true
+	}
+	return time.Time{}, false
+}
+
+// duration returns how long the node ran, and false if it has not finished.
+func (n *executionNode) duration() (time.Duration, bool) {
+	started, ok := parseExecutionTime(n.StartedAt)
+	if !ok {
+		return 0, false
+	}
+	finished, ok := parseExecutionTime(n.FinishedAt)
+	if !ok || finished.Before(started) {
+		return 0, false
+	}
+	return finished.Sub(started), true
+}
+
+// probeSuccessPercentage returns the probe success percentage of the fault, and false while it is awaited.
+func (c *chaosData) probeSuccessPercentage() (float64, bool) {
+	percentage, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(c.ProbeSuccessPercentage), "%"), 64)
+	return percentage, err == nil
+}
+
+// probeStatuses returns the outcome of each probe of the fault, if the ChaosResult was reported.
+func (c *chaosData) probeStatuses() []probeStatus {
+	if c.ChaosResult == nil {
+		return nil
+	}
+	return c.ChaosResult.Status.ProbeStatuses
+}
+
+// Workflow node types left out of the timeline, since they only group other steps
+var groupNodeTypes = map[string]bool{
+	"StepGroup": true,
+	"DAG":       true,
+	"TaskGroup": true,
+}
+
+// executionReport is the readable form of a run's execution data returned by get_experiment_run_details.
+type executionReport struct {
+	Phase           string          `json:"phase"`
+	Message         string          `json:"message,omitempty"`
+	StartedAt       string          `json:"startedAt,omitempty"`
+	FinishedAt      string          `json:"finishedAt,omitempty"`
+	DurationSeconds *float64        `json:"durationSeconds"`
+	Timeline        []string        `json:"timeline"`
+	Steps           []executionStep `json:"steps"`
+	Faults          []faultVerdict  `json:"faults"`
+}
+
+// executionStep is a workflow node with its timing.
+type executionStep struct {
+	Name            string   `json:"name"`
+	Type            string   `json:"type"`
+	Phase           string   `json:"phase"`
+	Message         string   `json:"message,omitempty"`
+	StartedAt       string   `json:"startedAt,omitempty"`
+	FinishedAt      string   `json:"finishedAt,omitempty"`
+	DurationSeconds *float64 `json:"durationSeconds"`
+}
+
+// faultVerdict is the outcome of the ChaosEngine of one fault step.
+type faultVerdict struct {
+	Step                   string        `json:"step"`
+	Fault                  string        `json:"fault"`
+	Engine                 string        `json:"engine,omitempty"`
+	Namespace              string        `json:"namespace,omitempty"`
+	Phase                  string        `json:"phase"`
+	Verdict                string        `json:"verdict"`
+	FailStep               string        `json:"failStep,omitempty"`
+	ProbeSuccessPercentage string        `json:"probeSuccessPercentage"`
+	ProbesPassed           int           `json:"probesPassed"`
+	ProbesFailed           int           `json:"probesFailed"`
+	Probes                 []probeResult `json:"probes,omitempty"`
+	PassedRuns             *int          `json:"passedRuns,omitempty"`
+	FailedRuns             *int          `json:"failedRuns,omitempty"`
+}
+
+// probeResult is the outcome of one resilience probe of a fault.
+type probeResult struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Mode        string `json:"mode"`
+	Verdict     string `json:"verdict"`
+	Description string `json:"description,omitempty"`
+}
+
+// formatExecutionTime renders an execution data timestamp as RFC 3339.
+func formatExecutionTime(value string) string {
+	if t, ok := parseExecutionTime(value); ok {
+		return t.Format(time.RFC3339)
+	}
+	return value
+}
+
+func durationSeconds(d time.Duration, ok bool) *float64 {
+	if !ok {
+		return nil
+	}
+	seconds := d.Seconds()
+	return &seconds
+}
+
+// steps returns the workflow nodes that do work, in the order they started. Nodes that have not started come last.
+func (e *executionData) steps() []*executionNode {
+	var nodes []*executionNode
+	for _, node := range e.Nodes {
+		if !groupNodeTypes[node.Type] {
+			nodes = append(nodes, node)
+		}
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		a, aok := parseExecutionTime(nodes[i].StartedAt)
+		b, bok := parseExecutionTime(nodes[j].StartedAt)
+		if aok != bok {
+			return aok
+		}
+		if !a.Equal(b) {
+			return a.Before(b)
+		}
+		return nodes[i].Name < nodes[j].Name
+	})
+	return nodes
+}
+
+// verdict returns the outcome of a fault node, counting its passed and failed probes.
+func (n *executionNode) verdict() faultVerdict {
+	data := n.ChaosData
+	verdict := faultVerdict{
+		Step:                   n.Name,
+		Fault:                  data.ExperimentName,
+		Engine:                 data.EngineName,
+		Namespace:              data.Namespace,
+		Phase:                  n.Phase,
+		Verdict:                data.ExperimentVerdict,
+		FailStep:               data.FailStep,
+		ProbeSuccessPercentage: data.ProbeSuccessPercentage,
+	}
+
+	for _, probe := range data.probeStatuses() {
+		verdict.Probes = append(verdict.Probes, probeResult{
+			Name:        probe.Name,
+			Type:        probe.Type,
+			Mode:        probe.Mode,
+			Verdict:     probe.Status.Verdict,
+			Description: probe.Status.Description,
+		})
+		switch {
+		case passed(probe.Status.Verdict):
+			verdict.ProbesPassed++
+		case strings.HasPrefix(strings.ToLower(probe.Status.Verdict), "fail"):
+			verdict.ProbesFailed++
+		}
+	}
+
+	if data.ChaosResult != nil && data.ChaosResult.Status.History != nil {
+		history := data.ChaosResult.Status.History
+		verdict.PassedRuns = &history.PassedRuns
+		verdict.FailedRuns = &history.FailedRuns
+	}
+	return verdict
+}
+
+// report turns the execution data into steps, fault verdicts and a timeline with one line per step, timed from
+// the start of the workflow.
+func (e *executionData) report() *executionReport {
+	started, hasStart := parseExecutionTime(e.StartedAt)
+	finished, hasFinish := parseExecutionTime(e.FinishedAt)
+
+	report := &executionReport{
+		Phase:      e.Phase,
+		Message:    e.Message,
+		StartedAt:  formatExecutionTime(e.StartedAt),
+		FinishedAt: formatExecutionTime(e.FinishedAt),
+		Timeline:   []string{},
+		Steps:      []executionStep{},
+		Faults:     []faultVerdict{},
+	}
+	if hasStart && hasFinish && !finished.Before(started) {
+		report.DurationSeconds = durationSeconds(finished.Sub(started), true)
+	}
+
+	for _, node := range e.steps() {
+		report.Steps = append(report.Steps, executionStep{
+			Name:            node.Name,
+			Type:            node.Type,
+			Phase:           node.Phase,
+			Message:         node.Message,
+			StartedAt:       formatExecutionTime(node.StartedAt),
+			FinishedAt:      formatExecutionTime(node.FinishedAt),
+			DurationSeconds: durationSeconds(node.duration()),
+		})
+
+		var line strings.Builder
+		if nodeStart, ok := parseExecutionTime(node.StartedAt); ok && hasStart {
+			fmt.Fprintf(&line, "+%s ", nodeStart.Sub(started))
+		} else {
+			line.WriteString("not started ")
+		}
+		fmt.Fprintf(&line, "%s (%s) %s", node.Name, node.Type, orNone(node.Phase))
+		if d, ok := node.duration(); ok {
+			fmt.Fprintf(&line, " in %s", d)
+		}
+
+		if node.Type == chaosEngineNodeType && node.ChaosData != nil {
+			verdict := node.verdict()
+			report.Faults = append(report.Faults, verdict)
+
+			fmt.Fprintf(&line, ": fault %s verdict %s", verdict.Fault, orNone(verdict.Verdict))
+			if verdict.FailStep != "" && !passed(verdict.Verdict) {
+				fmt.Fprintf(&line, ", failed at %q", verdict.FailStep)
+			}
+			if verdict.ProbeSuccessPercentage != "" {
+				fmt.Fprintf(&line, ", probe success %s%%", verdict.ProbeSuccessPercentage)
+			}
+			if len(verdict.Probes) > 0 {
+				fmt.Fprintf(&line, ", %d of %d probes passed", verdict.ProbesPassed, len(verdict.Probes))
+			}
+		} else if node.Message != "" && node.Phase != "Succeeded" {
+			fmt.Fprintf(&line, ": %s", node.Message)
+		}
+		report.Timeline = append(report.Timeline, line.String())
+	}
+	return report
+}
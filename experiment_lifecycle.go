@@ -0,0 +1,573 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"sigs.k8s.io/yaml"
+)
+
+// decodeWorkflowManifest parses an experiment manifest given as JSON or YAML into an Argo Workflow or CronWorkflow object.
+func decodeWorkflowManifest(text string) (map[string]interface{}, error) {
+	manifest := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(text), &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse experiment manifest: %w", err)
+	}
+
+	switch kind, _ := manifest["kind"].(string); kind {
+	case "Workflow", "CronWorkflow":
+		return manifest, nil
+	case "":
+		return nil, fmt.Errorf("experiment manifest has no kind; expected an Argo Workflow or CronWorkflow")
+	default:
+		return nil, fmt.Errorf("experiment manifest is a %s; expected an Argo Workflow or CronWorkflow", kind)
+	}
+}
+
+// manifestSchedule returns the cron schedule of a CronWorkflow manifest, or "" for a Workflow.
+func manifestSchedule(manifest map[string]interface{}) string {
+	if manifest["kind"] != "CronWorkflow" {
+		return ""
+	}
+	spec, _ := manifest["spec"].(map[string]interface{})
+	schedule, _ := spec["schedule"].(string)
+	return schedule
+}
+
+// scheduleSuspended reports whether the schedule of a CronWorkflow manifest is disabled.
+func scheduleSuspended(manifestText string) bool {
+	var manifest struct {
+		Kind string `json:"kind"`
+		Spec struct {
+			Suspend bool `json:"suspend"`
+		} `json:"spec"`
+	}
+	if yaml.Unmarshal([]byte(manifestText), &manifest) != nil {
+		return false
+	}
+	return manifest.Kind == "CronWorkflow" && manifest.Spec.Suspend
+}
+
+// scheduleChange reports whether saving manifest on infra starts or changes an active schedule, or changes what
+// an active schedule runs: its faults or its infrastructure. live is the experiment as Chaos Center has it, or nil
+// if it does not exist yet.
+func scheduleChange(live *Experiment, manifest map[string]interface{}, infra *Infra) bool {
+	manifestJSON, err := json.Marshal(manifest)
+	if err != nil {
+		return true
+	}
+	schedule := manifestSchedule(manifest)
+	if schedule == "" || scheduleSuspended(string(manifestJSON)) {
+		return false
+	}
+
+	switch {
+	case live == nil, live.CronSyntax != schedule, scheduleSuspended(live.ExperimentManifest):
+		return true
+	case live.Infra == nil || infra == nil || live.Infra.InfraID != infra.InfraID:
+		return true
+	}
+
+	liveManifest, err := parseExperimentManifest(live.ExperimentManifest)
+	if err != nil {
+		return true
+	}
+	nextManifest, err := parseExperimentManifest(string(manifestJSON))
+	if err != nil {
+		return true
+	}
+	return !reflect.DeepEqual(liveManifest.Faults, nextManifest.Faults)
+}
+
+// setManifestSchedule turns the manifest into a CronWorkflow running on cronSyntax, or into a plain Workflow if
+// cronSyntax is empty, keeping the workflow spec as is.
+func setManifestSchedule(manifest map[string]interface{}, cronSyntax string) {
+	spec, _ := manifest["spec"].(map[string]interface{})
+	if spec == nil {
+		spec = map[string]interface{}{}
+	}
+
+	if manifest["kind"] == "CronWorkflow" {
+		if cronSyntax != "" {
+			spec["schedule"] = cronSyntax
+			manifest["spec"] = spec
+			return
+		}
+		workflowSpec, _ := spec["workflowSpec"].(map[string]interface{})
+		manifest["kind"] = "Workflow"
+		manifest["spec"] = workflowSpec
+		return
+	}
+
+	if cronSyntax == "" {
+		return
+	}
+	manifest["kind"] = "CronWorkflow"
+	manifest["spec"] = map[string]interface{}{
+		"schedule":                cronSyntax,
+		"concurrencyPolicy":       "Forbid",
+		"startingDeadlineSeconds": 0,
+		"workflowSpec":            spec,
+	}
+}
+
+// retargetManifest points the manifest's metadata at another experiment ID, name and infrastructure, as
+// buildChaosWorkflow would have rendered it for them.
+func retargetManifest(manifest map[string]interface{}, experimentID, name string, infra *Infra) {
+	namespace := infra.InfraNamespace
+	if namespace == "" {
+		namespace = "litmus"
+	}
+
+	metadata, _ := manifest["metadata"].(map[string]interface{})
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+	delete(metadata, "generateName")
+	metadata["name"] = name
+	metadata["namespace"] = namespace
+
+	labels, _ := metadata["labels"].(map[string]interface{})
+	if labels == nil {
+		labels = map[string]interface{}{}
+	}
+	labels["infra_id"] = infra.InfraID
+	labels["workflow_id"] = experimentID
+	labels["revision_id"] = newUUID()
+	labels["subject"] = fmt.Sprintf("%s_%s", name, namespace)
+	metadata["labels"] = labels
+	manifest["metadata"] = metadata
+
+	spec, _ := manifest["spec"].(map[string]interface{})
+	if workflowSpec, ok := spec["workflowSpec"].(map[string]interface{}); ok {
+		spec = workflowSpec
+	}
+	arguments, _ := spec["arguments"].(map[string]interface{})
+	parameters, _ := arguments["parameters"].([]interface{})
+	for _, p := range parameters {
+		if param, ok := p.(map[string]interface{}); ok && param["name"] == "adminModeNamespace" {
+			param["value"] = namespace
+		}
+	}
+}
+
+// manifestWeightages returns a weightage for every fault of the manifest, keeping the weights of faults that
+// already had one and giving new faults the default weight.
+func manifestWeightages(manifest *experimentManifest, existing []Weightage) []Weightage {
+	weights := map[string]int{}
+	for _, w := range existing {
+		weights[w.FaultName] = w.Weightage
+	}
+
+	seen := map[string]bool{}
+	weightages := []Weightage{}
+	for _, fault := range manifest.Faults {
+		if seen[fault.Fault] {
+			continue
+		}
+		seen[fault.Fault] = true
+
+		weight, ok := weights[fault.Fault]
+		if !ok {
+			weight = defaultFaultWeight
+		}
+		weightages = append(weightages, Weightage{FaultName: fault.Fault, Weightage: weight})
+	}
+	return weightages
+}
+
+// experimentDraft is an experiment about to be created or updated in Chaos Center.
+type experimentDraft struct {
+	ID          string
+	Name        string
+	Description string
+	Tags        []string
+	Infra       *Infra
+	Manifest    map[string]interface{}
+	Weightages  []Weightage
+	IsCustom    bool
+
+	// Scheduling is set when the call starts or changes an active schedule, or what it runs (see scheduleChange).
+	// Blackout windows forbid it, and on infrastructure in a PROD environment it needs ConfirmationToken
+	Scheduling        bool
+	ConfirmationToken string
+}
+
+// saveExperiment checks a draft against blackout windows, the admission policy and, for schedules, the PROD
+// confirmation, then creates or updates it. It returns admission policy warnings, or a denial or preview to
+// return instead. action describes the call for messages,
+// such as "updating".
+func (s *LitmusChaosServer) saveExperiment(ctx context.Context, draft *experimentDraft, update bool, action string) ([]policyFinding, *ToolResult, error) {
+	env, err := s.infraEnvironment(ctx, draft.Infra)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot tell which environment infrastructure %s belongs to: %w", draft.Infra.InfraID, err)
+	}
+
+	if draft.Scheduling {
+		if err := s.checkBlackout(draft.Infra, env); err != nil {
+			return nil, nil, fmt.Errorf("experiment %s cannot be scheduled now: %w", draft.Name, err)
+		}
+	}
+
+	manifestJSON, err := json.Marshal(draft.Manifest)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal experiment manifest: %w", err)
+	}
+
+	parsed, err := parseExperimentManifest(string(manifestJSON))
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(parsed.Faults) == 0 {
+		return nil, nil, fmt.Errorf("experiment manifest of %s contains no ChaosEngine steps", draft.Name)
+	}
+
+	findings, denial, err := s.admitManifest(string(manifestJSON), draft.Name, action, draft.Infra, env)
+	if err != nil || denial != nil {
+		return nil, denial, err
+	}
+
+	weightages := manifestWeightages(parsed, draft.Weightages)
+
+	if draft.Scheduling {
+		exp := &Experiment{
+			Name:               draft.Name,
+			ExperimentManifest: string(manifestJSON),
+			CronSyntax:         manifestSchedule(draft.Manifest),
+			Weightages:         weightages,
+			Infra:              draft.Infra,
+		}
+		if update {
+			exp.ExperimentID = draft.ID
+		}
+		preview, err := s.guardProdSchedule(ctx, exp, env, findings, draft.ConfirmationToken, "save it with its schedule")
+		if err != nil || preview != nil {
+			return nil, preview, err
+		}
+	}
+
+	tags := draft.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	request := map[string]interface{}{
+		"experimentID":          draft.ID,
+		"experimentName":        draft.Name,
+		"experimentDescription": draft.Description,
+		"infraID":               draft.Infra.InfraID,
+		"experimentManifest":    string(manifestJSON),
+		"cronSyntax":            manifestSchedule(draft.Manifest),
+		"isCustomExperiment":    draft.IsCustom,
+		"weightages":            weightages,
+		"tags":                  tags,
+	}
+
+	mutation := `
+		mutation CreateChaosExperiment($request: ChaosExperimentRequest!, $projectID: ID!) {
+			createChaosExperiment(request: $request, projectID: $projectID) {
+				experimentID
+				experimentName
+				cronSyntax
+			}
+		}
+	`
+	field := "createChaosExperiment"
+	if update {
+		mutation = `
+		mutation UpdateChaosExperiment($request: ChaosExperimentRequest, $projectID: ID!) {
+			updateChaosExperiment(request: $request, projectID: $projectID) {
+				experimentID
+				experimentName
+				cronSyntax
+			}
+		}
+	`
+		field = "updateChaosExperiment"
+	}
+
+	variables := map[string]interface{}{
+		"request": request,
+	}
+
+	var result ChaosExperimentResponse
+	if err := s.query(ctx, mutation, variables, field, &result); err != nil {
+		return nil, nil, err
+	}
+	return findings, nil, nil
+}
+
+// experimentResult fetches an experiment and returns it in the get_chaos_experiment format, with any admission
+// policy warnings and the extra fields given.
+func (s *LitmusChaosServer) experimentResult(ctx context.Context, experimentID string, findings []policyFinding, extra map[string]interface{}) (*ToolResult, error) {
+	getExperiment, err := s.fetchExperiment(ctx, experimentID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := formatExperimentDetails(getExperiment)
+	for key, value := range extra {
+		response[key] = value
+	}
+	if len(findings) > 0 {
+		response["policyFindings"] = findings
+	}
+
+	responseJSON, _ := json.MarshalIndent(response, "", "  ")
+
+	return &ToolResult{
+		Content: []ContentItem{
+			{
+				Type: "text",
+				Text: string(responseJSON),
+			},
+		},
+	}, nil
+}
+
+// stringList reads an array argument as strings, returning nil if it is absent.
+func stringList(args map[string]interface{}, key string) []string {
+	items := getSliceFromArgs(args, key)
+	if items == nil {
+		return nil
+	}
+	list := make([]string, len(items))
+	for i, item := range items {
+		list[i] = fmt.Sprintf("%v", item)
+	}
+	return list
+}
+
+// updateChaosExperiment changes the manifest, description, tags or schedule of an experiment.
+func (s *LitmusChaosServer) updateChaosExperiment(ctx context.Context, args map[string]interface{}) (*ToolResult, error) {
+	experimentID := getStringFromArgs(args, "experimentId", "")
+	if experimentID == "" {
+		return nil, fmt.Errorf("experimentId is required")
+	}
+
+	getExperiment, err := s.fetchExperiment(ctx, experimentID)
+	if err != nil {
+		return nil, err
+	}
+	exp := getExperiment.ExperimentDetails
+	if exp.Infra == nil {
+		return nil, fmt.Errorf("experiment %s has no infrastructure", experimentID)
+	}
+
+	manifestText := exp.ExperimentManifest
+	if manifestArg := getStringFromArgs(args, "manifest", ""); manifestArg != "" {
+		manifestText = manifestArg
+	}
+	manifest, err := decodeWorkflowManifest(manifestText)
+	if err != nil {
+		return nil, err
+	}
+
+	draft := &experimentDraft{
+		ID:          exp.ExperimentID,
+		Name:        exp.Name,
+		Description: getStringFromArgs(args, "description", exp.Description),
+		Tags:        exp.Tags,
+		Infra:       exp.Infra,
+		Manifest:    manifest,
+		Weightages:  exp.Weightages,
+		IsCustom:    exp.IsCustomExperiment,
+
+		ConfirmationToken: getStringFromArgs(args, "confirmationToken", ""),
+	}
+	if tags := stringList(args, "tags"); tags != nil {
+		draft.Tags = tags
+	}
+
+	// An empty cronExpression removes the schedule; an absent one keeps the schedule of the manifest
+	if _, ok := args["cronExpression"]; ok {
+		setManifestSchedule(manifest, getStringFromArgs(args, "cronExpression", ""))
+	}
+	draft.Scheduling = scheduleChange(exp, manifest, exp.Infra)
+
+	findings, denial, err := s.saveExperiment(ctx, draft, true, "updating")
+	if err != nil || denial != nil {
+		return denial, err
+	}
+
+	return s.experimentResult(ctx, experimentID, findings, nil)
+}
+
+// deleteChaosExperiment deletes an experiment and returns what it was.
+func (s *LitmusChaosServer) deleteChaosExperiment(ctx context.Context, args map[string]interface{}) (*ToolResult, error) {
+	experimentID := getStringFromArgs(args, "experimentId", "")
+	if experimentID == "" {
+		return nil, fmt.Errorf("experimentId is required")
+	}
+
+	getExperiment, err := s.fetchExperiment(ctx, experimentID)
+	if err != nil {
+		return nil, err
+	}
+
+	mutation := `
+		mutation DeleteChaosExperiment($experimentIDs: [String!]!, $projectID: ID!) {
+			deleteChaosExperiment(experimentIDs: $experimentIDs, projectID: $projectID)
+		}
+	`
+
+	variables := map[string]interface{}{
+		"experimentIDs": []string{experimentID},
+	}
+
+	var deleted bool
+	if err := s.query(ctx, mutation, variables, "deleteChaosExperiment", &deleted); err != nil {
+		return nil, err
+	}
+	if !deleted {
+		return nil, fmt.Errorf("Chaos Center did not delete experiment %s", experimentID)
+	}
+
+	response := formatExperimentDetails(getExperiment)
+	response["deleted"] = true
+
+	responseJSON, _ := json.MarshalIndent(response, "", "  ")
+
+	return &ToolResult{
+		Content: []ContentItem{
+			{
+				Type: "text",
+				Text: string(responseJSON),
+			},
+		},
+	}, nil
+}
+
+// cloneChaosExperiment copies an experiment under a new name, optionally onto another infrastructure.
+func (s *LitmusChaosServer) cloneChaosExperiment(ctx context.Context, args map[string]interface{}) (*ToolResult, error) {
+	experimentID := getStringFromArgs(args, "experimentId", "")
+	if experimentID == "" {
+		return nil, fmt.Errorf("experimentId is required")
+	}
+
+	getExperiment, err := s.fetchExperiment(ctx, experimentID)
+	if err != nil {
+		return nil, err
+	}
+	exp := getExperiment.ExperimentDetails
+
+	name := sanitizeResourceName(getStringFromArgs(args, "name", exp.Name+"-copy"))
+	if name == "" {
+		return nil, fmt.Errorf("name must contain at least one alphanumeric character")
+	}
+
+	infra := exp.Infra
+	if infraID := getStringFromArgs(args, "infraId", ""); infraID != "" && (infra == nil || infraID != infra.InfraID) {
+		if infra, err = s.fetchInfra(ctx, infraID); err != nil {
+			return nil, fmt.Errorf("failed to fetch infrastructure %s: %w", infraID, err)
+		}
+	}
+	if infra == nil {
+		return nil, fmt.Errorf("experiment %s has no infrastructure; pass infraId to clone it onto one", experimentID)
+	}
+
+	manifest, err := decodeWorkflowManifest(exp.ExperimentManifest)
+	if err != nil {
+		return nil, err
+	}
+
+	cloneID := newUUID()
+	retargetManifest(manifest, cloneID, name, infra)
+
+	draft := &experimentDraft{
+		ID:          cloneID,
+		Name:        name,
+		Description: getStringFromArgs(args, "description", exp.Description),
+		Tags:        exp.Tags,
+		Infra:       infra,
+		Manifest:    manifest,
+		Weightages:  exp.Weightages,
+		IsCustom:    exp.IsCustomExperiment,
+		Scheduling:  scheduleChange(nil, manifest, infra),
+
+		ConfirmationToken: getStringFromArgs(args, "confirmationToken", ""),
+	}
+	if tags := stringList(args, "tags"); tags != nil {
+		draft.Tags = tags
+	}
+
+	findings, denial, err := s.saveExperiment(ctx, draft, false, "cloning")
+	if err != nil || denial != nil {
+		return denial, err
+	}
+
+	return s.experimentResult(ctx, cloneID, findings, map[string]interface{}{
+		"clonedFrom": experimentID,
+	})
+}
+
+// enableExperimentSchedule resumes the schedule of a cron experiment.
+func (s *LitmusChaosServer) enableExperimentSchedule(ctx context.Context, args map[string]interface{}) (*ToolResult, error) {
+	return s.setExperimentScheduleState(ctx, args, true)
+}
+
+// disableExperimentSchedule suspends the schedule of a cron experiment without deleting it.
+func (s *LitmusChaosServer) disableExperimentSchedule(ctx context.Context, args map[string]interface{}) (*ToolResult, error) {
+	return s.setExperimentScheduleState(ctx, args, false)
+}
+
+func (s *LitmusChaosServer) setExperimentScheduleState(ctx context.Context, args map[string]interface{}, enable bool) (*ToolResult, error) {
+	experimentID := getStringFromArgs(args, "experimentId", "")
+	if experimentID == "" {
+		return nil, fmt.Errorf("experimentId is required")
+	}
+
+	getExperiment, err := s.fetchExperiment(ctx, experimentID)
+	if err != nil {
+		return nil, err
+	}
+	exp := getExperiment.ExperimentDetails
+	if exp.CronSyntax == "" {
+		return nil, fmt.Errorf("experiment %s has no schedule; set one with update_chaos_experiment and cronExpression", exp.Name)
+	}
+
+	var findings []policyFinding
+	if enable {
+		env, err := s.infraEnvironment(ctx, exp.Infra)
+		if err != nil {
+			return nil, fmt.Errorf("cannot tell which environment experiment %s targets: %w", experimentID, err)
+		}
+		if err := s.checkBlackout(exp.Infra, env); err != nil {
+			return nil, fmt.Errorf("the schedule of experiment %s cannot be enabled now: %w", exp.Name, err)
+		}
+
+		var denial *ToolResult
+		findings, denial, err = s.admitManifest(exp.ExperimentManifest, exp.Name, "scheduling", exp.Infra, env)
+		if err != nil || denial != nil {
+			return denial, err
+		}
+
+		preview, err := s.guardProdSchedule(ctx, exp, env, findings, getStringFromArgs(args, "confirmationToken", ""), "enable its schedule")
+		if err != nil || preview != nil {
+			return preview, err
+		}
+	}
+
+	mutation := `
+		mutation UpdateCronExperimentState($experimentID: String!, $disable: Boolean!, $projectID: ID!) {
+			updateCronExperimentState(experimentID: $experimentID, disable: $disable, projectID: $projectID)
+		}
+	`
+
+	variables := map[string]interface{}{
+		"experimentID": experimentID,
+		"disable":      !enable,
+	}
+
+	var updated bool
+	if err := s.query(ctx, mutation, variables, "updateCronExperimentState", &updated); err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, fmt.Errorf("Chaos Center did not change the schedule of experiment %s", exp.Name)
+	}
+
+	return s.experimentResult(ctx, experimentID, findings, nil)
+}
@@ -0,0 +1,493 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Defaults used when generating Chaos Center experiment workflows
+const (
+	defaultLitmusVersion      = "3.16.0"
+	defaultFaultWeight        = 10
+	defaultProbeMode          = "SOT"
+	defaultChaosServiceAcct   = "litmus-admin"
+	defaultWorkflowServiceAcc = "argo-chaos"
+	adminModeNamespaceParam   = "{{workflow.parameters.adminModeNamespace}}"
+)
+
+// probeRef references a resilience probe attached to a fault, serialized into the ChaosEngine probeRef annotation.
+type probeRef struct {
+	Name string `json:"name"`
+	Mode string `json:"mode"`
+}
+
+// faultSpec describes a single fault step requested for a generated experiment.
+type faultSpec struct {
+	Name         string
+	Category     string
+	Weight       int
+	AppNamespace string
+	AppLabel     string
+	AppKind      string
+	Duration     string
+	Env          map[string]string
+	Probes       []probeRef
+}
+
+// hubFault holds the ChaosHub definition of a fault: the ChaosExperiment CR and its sample ChaosEngine, both as YAML.
+type hubFault struct {
+	Fault  string `json:"fault"`
+	Engine string `json:"engine"`
+}
+
+// workflowOptions carries the experiment-level settings used when rendering the Argo workflow.
+type workflowOptions struct {
+	Name         string
+	ExperimentID string
+	InfraID      string
+	Namespace    string
+	Version      string
+	CronSyntax   string
+	Parallel     bool
+}
+
+var dnsLabelInvalidChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// sanitizeResourceName converts a free-form name into a valid Kubernetes resource name.
+func sanitizeResourceName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = dnsLabelInvalidChars.ReplaceAllString(strings.ReplaceAll(name, " ", "-"), "-")
+	name = strings.Trim(name, "-")
+	if len(name) > 53 {
+		name = strings.TrimRight(name[:53], "-")
+	}
+	return name
+}
+
+// newUUID returns a random RFC 4122 version 4 UUID.
+func newUUID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("failed to read random bytes: %v", err))
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// parseFaultSpecs converts the create_chaos_experiment "faults" argument into fault specs.
+func parseFaultSpecs(faults []interface{}) ([]faultSpec, error) {
+	specs := make([]faultSpec, 0, len(faults))
+	for i, fault := range faults {
+		faultMap, ok := fault.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("faults[%d] must be an object", i)
+		}
+
+		name := getStringFromArgs(faultMap, "name", "")
+		if name == "" {
+			return nil, fmt.Errorf("faults[%d].name is required", i)
+		}
+
+		weight := getIntFromArgs(faultMap, "weight", defaultFaultWeight)
+		if weight < 0 || weight > 10 {
+			return nil, fmt.Errorf("faults[%d].weight must be between 0 and 10", i)
+		}
+
+		duration, err := parseDurationSeconds(faultMap["duration"])
+		if err != nil {
+			return nil, fmt.Errorf("faults[%d].duration %w", i, err)
+		}
+
+		spec := faultSpec{
+			Name:         name,
+			Category:     getStringFromArgs(faultMap, "category", ""),
+			Weight:       weight,
+			AppNamespace: getStringFromArgs(faultMap, "appNamespace", ""),
+			AppLabel:     getStringFromArgs(faultMap, "appLabel", ""),
+			AppKind:      getStringFromArgs(faultMap, "appKind", ""),
+			Duration:     duration,
+			Env:          map[string]string{},
+		}
+
+		if parameters := getMapFromArgs(faultMap, "parameters"); parameters != nil {
+			for key, value := range parameters {
+				spec.Env[strings.ToUpper(key)] = fmt.Sprintf("%v", value)
+			}
+		}
+
+		for j, p := range getSliceFromArgs(faultMap, "probes") {
+			probeMap, ok := p.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("faults[%d].probes[%d] must be an object", i, j)
+			}
+			probeName := getStringFromArgs(probeMap, "name", "")
+			if probeName == "" {
+				return nil, fmt.Errorf("faults[%d].probes[%d].name is required", i, j)
+			}
+			spec.Probes = append(spec.Probes, probeRef{
+				Name: probeName,
+				Mode: getStringFromArgs(probeMap, "mode", defaultProbeMode),
+			})
+		}
+
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// parseDurationSeconds converts a fault duration, given as a number of seconds or as a string such as "60", "90s"
+// or "5m", into the whole number of seconds TOTAL_CHAOS_DURATION expects. A missing duration yields "".
+func parseDurationSeconds(value interface{}) (string, error) {
+	var seconds float64
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case float64:
+		seconds = v
+	case string:
+		text := strings.TrimSpace(v)
+		if text == "" {
+			return "", nil
+		}
+		if n, err := strconv.ParseFloat(text, 64); err == nil {
+			seconds = n
+		} else {
+			d, err := time.ParseDuration(text)
+			if err != nil {
+				return "", fmt.Errorf("must be a number of seconds or a duration such as 90s or 5m, got %q", v)
+			}
+			seconds = d.Seconds()
+		}
+	default:
+		return "", fmt.Errorf("must be a number of seconds or a duration string, got %v", v)
+	}
+
+	if seconds <= 0 || seconds != math.Trunc(seconds) {
+		return "", fmt.Errorf("must be a positive whole number of seconds, got %v", value)
+	}
+	return strconv.FormatInt(int64(seconds), 10), nil
+}
+
+// buildWeightages returns the Chaos Center weightages for the given faults. Chaos Center keys
+// weightages by fault name, so a fault used more than once must carry the same weight each time.
+func buildWeightages(faults []faultSpec) ([]Weightage, error) {
+	seen := map[string]int{}
+	weightages := make([]Weightage, 0, len(faults))
+	for _, fault := range faults {
+		if weight, ok := seen[fault.Name]; ok {
+			if weight != fault.Weight {
+				return nil, fmt.Errorf("fault %s is used more than once with different weights (%d and %d)", fault.Name, weight, fault.Weight)
+			}
+			continue
+		}
+		seen[fault.Name] = fault.Weight
+		weightages = append(weightages, Weightage{FaultName: fault.Name, Weightage: fault.Weight})
+	}
+	return weightages, nil
+}
+
+// stepNames returns a unique workflow step name for each fault, suffixing repeated faults with their occurrence.
+func stepNames(faults []faultSpec) []string {
+	counts := map[string]int{}
+	names := make([]string, len(faults))
+	for i, fault := range faults {
+		counts[fault.Name]++
+		names[i] = fault.Name
+		if counts[fault.Name] > 1 {
+			names[i] = fmt.Sprintf("%s-%d", fault.Name, counts[fault.Name])
+		}
+	}
+	return names
+}
+
+// buildChaosEngine renders the ChaosEngine for a fault step, starting from the ChaosHub sample engine so
+// fault-specific defaults are kept, then applying the requested appinfo, env overrides and probe references.
+func buildChaosEngine(opts workflowOptions, stepName string, fault faultSpec, def hubFault) (string, error) {
+	engine := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(def.Engine), &engine); err != nil {
+		return "", fmt.Errorf("failed to parse ChaosHub engine for %s: %w", fault.Name, err)
+	}
+
+	labels := map[string]interface{}{
+		"workflow_run_id": "{{ workflow.uid }}",
+		"workflow_name":   opts.Name,
+	}
+	metadata := map[string]interface{}{
+		"generateName": stepName,
+		"namespace":    adminModeNamespaceParam,
+		"labels":       labels,
+	}
+	if len(fault.Probes) > 0 {
+		probeJSON, err := json.Marshal(fault.Probes)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal probe references: %w", err)
+		}
+		metadata["annotations"] = map[string]interface{}{
+			"probeRef": string(probeJSON),
+		}
+	}
+	engine["apiVersion"] = "litmuschaos.io/v1alpha1"
+	engine["kind"] = "ChaosEngine"
+	engine["metadata"] = metadata
+
+	spec, _ := engine["spec"].(map[string]interface{})
+	if spec == nil {
+		spec = map[string]interface{}{}
+	}
+
+	appinfo, _ := spec["appinfo"].(map[string]interface{})
+	if appinfo == nil {
+		appinfo = map[string]interface{}{}
+	}
+	if fault.AppNamespace != "" {
+		appinfo["appns"] = fault.AppNamespace
+	}
+	if fault.AppLabel != "" {
+		appinfo["applabel"] = fault.AppLabel
+	}
+	if fault.AppKind != "" {
+		appinfo["appkind"] = fault.AppKind
+	}
+	if len(appinfo) > 0 {
+		spec["appinfo"] = appinfo
+	}
+
+	spec["engineState"] = "active"
+	spec["chaosServiceAccount"] = defaultChaosServiceAcct
+
+	overrides := map[string]string{}
+	for key, value := range fault.Env {
+		overrides[key] = value
+	}
+	if fault.Duration != "" {
+		overrides["TOTAL_CHAOS_DURATION"] = fault.Duration
+	}
+
+	var experiment map[string]interface{}
+	if experiments, ok := spec["experiments"].([]interface{}); ok && len(experiments) > 0 {
+		experiment, _ = experiments[0].(map[string]interface{})
+	}
+	if experiment == nil {
+		experiment = map[string]interface{}{}
+	}
+	experiment["name"] = fault.Name
+
+	expSpec, _ := experiment["spec"].(map[string]interface{})
+	if expSpec == nil {
+		expSpec = map[string]interface{}{}
+	}
+	components, _ := expSpec["components"].(map[string]interface{})
+	if components == nil {
+		components = map[string]interface{}{}
+	}
+	components["env"] = mergeEnv(components["env"], overrides)
+	expSpec["components"] = components
+	experiment["spec"] = expSpec
+	spec["experiments"] = []interface{}{experiment}
+	engine["spec"] = spec
+
+	engineYAML, err := yaml.Marshal(engine)
+	if err != nil {
+		return "", fmt.Errorf("failed to render ChaosEngine for %s: %w", fault.Name, err)
+	}
+	return string(engineYAML), nil
+}
+
+// mergeEnv applies overrides onto a ChaosEngine env list, preserving the order of existing entries
+// and appending new variables in name order.
+func mergeEnv(existing interface{}, overrides map[string]string) []interface{} {
+	env := []interface{}{}
+	applied := map[string]bool{}
+
+	if list, ok := existing.([]interface{}); ok {
+		for _, item := range list {
+			entry, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _ := entry["name"].(string)
+			if value, ok := overrides[name]; ok {
+				entry = map[string]interface{}{"name": name, "value": value}
+				applied[name] = true
+			}
+			env = append(env, entry)
+		}
+	}
+
+	remaining := make([]string, 0, len(overrides))
+	for name := range overrides {
+		if !applied[name] {
+			remaining = append(remaining, name)
+		}
+	}
+	sort.Strings(remaining)
+	for _, name := range remaining {
+		env = append(env, map[string]interface{}{"name": name, "value": overrides[name]})
+	}
+	return env
+}
+
+// buildChaosWorkflow renders the Argo workflow Chaos Center expects for an experiment: an install-chaos-faults
+// step applying the ChaosHub fault definitions, one ChaosEngine step per fault and a cleanup step.
+func buildChaosWorkflow(opts workflowOptions, faults []faultSpec, defs map[string]hubFault) (map[string]interface{}, error) {
+	names := stepNames(faults)
+	k8sImage := fmt.Sprintf("docker.io/litmuschaos/k8s:%s", opts.Version)
+	checkerImage := fmt.Sprintf("docker.io/litmuschaos/litmus-checker:%s", opts.Version)
+
+	installArtifacts := []interface{}{}
+	installed := map[string]bool{}
+	faultSteps := []interface{}{}
+	faultTemplates := []interface{}{}
+
+	for i, fault := range faults {
+		def, ok := defs[fault.Name]
+		if !ok {
+			return nil, fmt.Errorf("no ChaosHub definition found for fault %s", fault.Name)
+		}
+
+		if !installed[fault.Name] {
+			installed[fault.Name] = true
+			installArtifacts = append(installArtifacts, map[string]interface{}{
+				"name": fault.Name,
+				"path": fmt.Sprintf("/tmp/%s.yaml", fault.Name),
+				"raw": map[string]interface{}{
+					"data": def.Fault,
+				},
+			})
+		}
+
+		engineYAML, err := buildChaosEngine(opts, names[i], fault, def)
+		if err != nil {
+			return nil, err
+		}
+
+		enginePath := fmt.Sprintf("/tmp/chaosengine-%s.yaml", names[i])
+		faultSteps = append(faultSteps, map[string]interface{}{
+			"name":     names[i],
+			"template": names[i],
+		})
+		faultTemplates = append(faultTemplates, map[string]interface{}{
+			"name": names[i],
+			"inputs": map[string]interface{}{
+				"artifacts": []interface{}{
+					map[string]interface{}{
+						"name": names[i],
+						"path": enginePath,
+						"raw": map[string]interface{}{
+							"data": engineYAML,
+						},
+					},
+				},
+			},
+			"container": map[string]interface{}{
+				"name":  "",
+				"image": checkerImage,
+				"args": []string{
+					"-file=" + enginePath,
+					"-saveName=/tmp/engine-name",
+				},
+			},
+		})
+	}
+
+	steps := []interface{}{
+		[]interface{}{map[string]interface{}{"name": "install-chaos-faults", "template": "install-chaos-faults"}},
+	}
+	if opts.Parallel {
+		steps = append(steps, faultSteps)
+	} else {
+		for _, step := range faultSteps {
+			steps = append(steps, []interface{}{step})
+		}
+	}
+	steps = append(steps, []interface{}{map[string]interface{}{"name": "cleanup-chaos-resources", "template": "cleanup-chaos-resources"}})
+
+	templates := []interface{}{
+		map[string]interface{}{
+			"name":  opts.Name,
+			"steps": steps,
+		},
+		map[string]interface{}{
+			"name": "install-chaos-faults",
+			"inputs": map[string]interface{}{
+				"artifacts": installArtifacts,
+			},
+			"container": map[string]interface{}{
+				"name":    "",
+				"image":   k8sImage,
+				"command": []string{"sh", "-c"},
+				"args":    []string{fmt.Sprintf("kubectl apply -f /tmp/ -n %s && sleep 30", adminModeNamespaceParam)},
+			},
+		},
+	}
+	templates = append(templates, faultTemplates...)
+	templates = append(templates, map[string]interface{}{
+		"name": "cleanup-chaos-resources",
+		"container": map[string]interface{}{
+			"name":    "",
+			"image":   k8sImage,
+			"command": []string{"sh", "-c"},
+			"args":    []string{fmt.Sprintf("kubectl delete chaosengine -l workflow_run_id={{workflow.uid}} -n %s", adminModeNamespaceParam)},
+		},
+	})
+
+	workflowSpec := map[string]interface{}{
+		"entrypoint": opts.Name,
+		"templates":  templates,
+		"arguments": map[string]interface{}{
+			"parameters": []interface{}{
+				map[string]interface{}{"name": "adminModeNamespace", "value": opts.Namespace},
+			},
+		},
+		"serviceAccountName": defaultWorkflowServiceAcc,
+		"podGC": map[string]interface{}{
+			"strategy": "OnWorkflowCompletion",
+		},
+		"securityContext": map[string]interface{}{
+			"runAsUser":    1000,
+			"runAsNonRoot": true,
+		},
+	}
+
+	metadata := map[string]interface{}{
+		"name":      opts.Name,
+		"namespace": opts.Namespace,
+		"labels": map[string]interface{}{
+			"infra_id":    opts.InfraID,
+			"workflow_id": opts.ExperimentID,
+			"revision_id": newUUID(),
+			"subject":     fmt.Sprintf("%s_%s", opts.Name, opts.Namespace),
+		},
+	}
+
+	if opts.CronSyntax != "" {
+		return map[string]interface{}{
+			"apiVersion": "argoproj.io/v1alpha1",
+			"kind":       "CronWorkflow",
+			"metadata":   metadata,
+			"spec": map[string]interface{}{
+				"schedule":                opts.CronSyntax,
+				"concurrencyPolicy":       "Forbid",
+				"startingDeadlineSeconds": 0,
+				"workflowSpec":            workflowSpec,
+			},
+		}, nil
+	}
+
+	return map[string]interface{}{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Workflow",
+		"metadata":   metadata,
+		"spec":       workflowSpec,
+	}, nil
+}
@@ -0,0 +1,363 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Annotation naming the workflow step of a ChaosEngine exported as a document of its own
+const exportStepAnnotation = "litmus-mcp-server/step"
+
+// Metadata fields that Kubernetes, Argo or Chaos Center set and that differ between copies of an experiment
+var volatileMetadataFields = []string{"uid", "resourceVersion", "creationTimestamp", "generation", "managedFields", "selfLink", "namespace"}
+
+// Labels Chaos Center derives from the experiment ID, revision and infrastructure
+var volatileLabels = []string{"revision_id", "workflow_id", "infra_id", "subject"}
+
+// manifestTemplates returns the templates of a Workflow or CronWorkflow manifest.
+func manifestTemplates(manifest map[string]interface{}) []interface{} {
+	spec, _ := manifest["spec"].(map[string]interface{})
+	if workflowSpec, ok := spec["workflowSpec"].(map[string]interface{}); ok {
+		spec = workflowSpec
+	}
+	templates, _ := spec["templates"].([]interface{})
+	return templates
+}
+
+// engineArtifact returns the raw artifact of a template that holds a single ChaosEngine, and the engine.
+func engineArtifact(template map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+	inputs, _ := template["inputs"].(map[string]interface{})
+	artifacts, _ := inputs["artifacts"].([]interface{})
+	for _, a := range artifacts {
+		artifact, _ := a.(map[string]interface{})
+		raw, _ := artifact["raw"].(map[string]interface{})
+		data, _ := raw["data"].(string)
+		if docs := splitYAMLDocuments(data); len(docs) == 1 {
+			engine := map[string]interface{}{}
+			if yaml.Unmarshal([]byte(docs[0]), &engine) == nil && engine["kind"] == "ChaosEngine" {
+				return raw, engine
+			}
+		}
+	}
+	return nil, nil
+}
+
+// stripVolatileMetadata removes the fields of an object's metadata that do not belong in version control.
+func stripVolatileMetadata(object map[string]interface{}) {
+	delete(object, "status")
+
+	metadata, ok := object["metadata"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	for _, field := range volatileMetadataFields {
+		delete(metadata, field)
+	}
+	if labels, ok := metadata["labels"].(map[string]interface{}); ok {
+		for _, label := range volatileLabels {
+			delete(labels, label)
+		}
+		if len(labels) == 0 {
+			delete(metadata, "labels")
+		}
+	}
+}
+
+// exportExperimentYAML renders an experiment manifest as multi-document YAML: the workflow without volatile
+// fields, followed by each ChaosEngine as a document of its own, annotated with the step it belongs to.
+func exportExperimentYAML(manifestText string) (string, error) {
+	manifest, err := decodeWorkflowManifest(manifestText)
+	if err != nil {
+		return "", err
+	}
+	stripVolatileMetadata(manifest)
+
+	var engines []map[string]interface{}
+	for _, t := range manifestTemplates(manifest) {
+		template, _ := t.(map[string]interface{})
+		raw, engine := engineArtifact(template)
+		if engine == nil {
+			continue
+		}
+
+		metadata, _ := engine["metadata"].(map[string]interface{})
+		if metadata == nil {
+			metadata = map[string]interface{}{}
+		}
+		annotations, _ := metadata["annotations"].(map[string]interface{})
+		if annotations == nil {
+			annotations = map[string]interface{}{}
+		}
+		annotations[exportStepAnnotation] = template["name"]
+		metadata["annotations"] = annotations
+		engine["metadata"] = metadata
+
+		delete(raw, "data")
+		engines = append(engines, engine)
+	}
+
+	docs := make([]string, 0, len(engines)+1)
+	for _, object := range append([]map[string]interface{}{manifest}, engines...) {
+		doc, err := yaml.Marshal(object)
+		if err != nil {
+			return "", fmt.Errorf("failed to render experiment YAML: %w", err)
+		}
+		docs = append(docs, string(doc))
+	}
+	return strings.Join(docs, "---\n"), nil
+}
+
+// importExperimentYAML reads multi-document YAML as written by exportExperimentYAML, embedding the ChaosEngine
+// documents back into the steps they are annotated with. Engines may also stay embedded in the workflow.
+func importExperimentYAML(text string) (map[string]interface{}, error) {
+	var manifest map[string]interface{}
+	var engines []map[string]interface{}
+	for i, doc := range splitYAMLDocuments(text) {
+		object := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(doc), &object); err != nil {
+			return nil, fmt.Errorf("document %d: %w", i+1, err)
+		}
+
+		switch kind, _ := object["kind"].(string); kind {
+		case "Workflow", "CronWorkflow":
+			if manifest != nil {
+				return nil, fmt.Errorf("document %d: only one Workflow or CronWorkflow is allowed", i+1)
+			}
+			manifest = object
+		case "ChaosEngine":
+			engines = append(engines, object)
+		default:
+			return nil, fmt.Errorf("document %d: expected a Workflow, CronWorkflow or ChaosEngine, got kind %q", i+1, kind)
+		}
+	}
+	if manifest == nil {
+		return nil, fmt.Errorf("no Workflow or CronWorkflow document found")
+	}
+
+	steps := map[string]map[string]interface{}{}
+	for _, t := range manifestTemplates(manifest) {
+		if template, ok := t.(map[string]interface{}); ok {
+			if name, _ := template["name"].(string); name != "" {
+				steps[name] = template
+			}
+		}
+	}
+
+	for _, engine := range engines {
+		metadata, _ := engine["metadata"].(map[string]interface{})
+		annotations, _ := metadata["annotations"].(map[string]interface{})
+		step, _ := annotations[exportStepAnnotation].(string)
+		if step == "" {
+			return nil, fmt.Errorf("ChaosEngine %v has no %s annotation naming its workflow step", metadata["generateName"], exportStepAnnotation)
+		}
+		template, ok := steps[step]
+		if !ok {
+			return nil, fmt.Errorf("ChaosEngine for step %s: the workflow has no such step", step)
+		}
+
+		delete(annotations, exportStepAnnotation)
+		if len(annotations) == 0 {
+			delete(metadata, "annotations")
+		}
+		engineYAML, err := yaml.Marshal(engine)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render ChaosEngine for step %s: %w", step, err)
+		}
+
+		raw, err := emptyEngineArtifact(template)
+		if err != nil {
+			return nil, fmt.Errorf("ChaosEngine for step %s: %w", step, err)
+		}
+		raw["data"] = string(engineYAML)
+	}
+
+	for name, template := range steps {
+		if raw, _ := emptyEngineArtifact(template); raw != nil {
+			return nil, fmt.Errorf("step %s has no ChaosEngine; add a ChaosEngine document annotated with %s: %s", name, exportStepAnnotation, name)
+		}
+	}
+	return manifest, nil
+}
+
+// emptyEngineArtifact returns the raw artifact of a step whose ChaosEngine was exported as its own document.
+func emptyEngineArtifact(template map[string]interface{}) (map[string]interface{}, error) {
+	inputs, _ := template["inputs"].(map[string]interface{})
+	artifacts, _ := inputs["artifacts"].([]interface{})
+	for _, a := range artifacts {
+		artifact, _ := a.(map[string]interface{})
+		raw, ok := artifact["raw"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if data, _ := raw["data"].(string); strings.TrimSpace(data) == "" {
+			return raw, nil
+		}
+	}
+	return nil, fmt.Errorf("the step has no artifact left empty for its ChaosEngine")
+}
+
+// exportChaosExperiment returns an experiment's manifest as multi-document YAML that can be kept in git.
+func (s *LitmusChaosServer) exportChaosExperiment(ctx context.Context, args map[string]interface{}) (*ToolResult, error) {
+	experimentID := getStringFromArgs(args, "experimentId", "")
+	if experimentID == "" {
+		return nil, fmt.Errorf("experimentId is required")
+	}
+
+	getExperiment, err := s.fetchExperiment(ctx, experimentID)
+	if err != nil {
+		return nil, err
+	}
+	exp := getExperiment.ExperimentDetails
+
+	exported, err := exportExperimentYAML(exp.ExperimentManifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export experiment %s: %w", exp.Name, err)
+	}
+
+	return &ToolResult{
+		Content: []ContentItem{
+			{
+				Type: "text",
+				Text: fmt.Sprintf("# Chaos experiment %s, exported from experiment %s\n%s", exp.Name, exp.ExperimentID, exported),
+			},
+		},
+	}, nil
+}
+
+// importChaosExperiment validates experiment YAML and creates the experiment on an infrastructure, or updates it
+// if it was given by experimentId or an experiment of the same name already exists there.
+func (s *LitmusChaosServer) importChaosExperiment(ctx context.Context, args map[string]interface{}) (*ToolResult, error) {
+	text := getStringFromArgs(args, "yaml", "")
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("yaml is required")
+	}
+
+	manifest, err := importExperimentYAML(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid experiment YAML: %w", err)
+	}
+
+	metadata, _ := manifest["metadata"].(map[string]interface{})
+	name, _ := metadata["name"].(string)
+	if name == "" {
+		generateName, _ := metadata["generateName"].(string)
+		name = strings.TrimSuffix(generateName, "-")
+	}
+	name = sanitizeResourceName(getStringFromArgs(args, "name", name))
+	if name == "" {
+		return nil, fmt.Errorf("name is required when the workflow has no metadata.name")
+	}
+
+	var existing *Experiment
+	if experimentID := getStringFromArgs(args, "experimentId", ""); experimentID != "" {
+		getExperiment, err := s.fetchExperiment(ctx, experimentID)
+		if err != nil {
+			return nil, err
+		}
+		existing = getExperiment.ExperimentDetails
+	}
+
+	infraID := getStringFromArgs(args, "infraId", "")
+	if infraID == "" && existing != nil && existing.Infra != nil {
+		infraID = existing.Infra.InfraID
+	}
+	if infraID == "" {
+		infraID = s.config.DefaultInfraID
+	}
+	if infraID == "" {
+		return nil, fmt.Errorf("infraId is required")
+	}
+	infra, err := s.fetchInfra(ctx, infraID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch infrastructure %s: %w", infraID, err)
+	}
+
+	if existing == nil {
+		if existing, err = s.findExperiment(ctx, name, infraID); err != nil {
+			return nil, err
+		}
+	}
+
+	draft := &experimentDraft{
+		ID:          newUUID(),
+		Name:        name,
+		Description: getStringFromArgs(args, "description", "Imported via MCP Server"),
+		Tags:        stringList(args, "tags"),
+		Infra:       infra,
+		Manifest:    manifest,
+		IsCustom:    true,
+		Scheduling:  scheduleChange(existing, manifest, infra),
+
+		ConfirmationToken: getStringFromArgs(args, "confirmationToken", ""),
+	}
+	action := "created"
+	if existing != nil {
+		action = "updated"
+		draft.ID = existing.ExperimentID
+		draft.Weightages = existing.Weightages
+		draft.IsCustom = existing.IsCustomExperiment
+		draft.Description = getStringFromArgs(args, "description", existing.Description)
+		if draft.Tags == nil {
+			draft.Tags = existing.Tags
+		}
+	}
+	retargetManifest(manifest, draft.ID, name, infra)
+
+	findings, denial, err := s.saveExperiment(ctx, draft, existing != nil, "importing")
+	if err != nil || denial != nil {
+		return denial, err
+	}
+
+	return s.experimentResult(ctx, draft.ID, findings, map[string]interface{}{
+		"action": action,
+	})
+}
+
+// findExperiment returns the experiment with exactly this name on the infrastructure, or nil if there is none.
+func (s *LitmusChaosServer) findExperiment(ctx context.Context, name, infraID string) (*Experiment, error) {
+	query := `
+		query ListExperiment($projectID: ID!, $request: ListExperimentRequest!) {
+			listExperiment(projectID: $projectID, request: $request) {
+				totalNoOfExperiments
+				experiments {
+					experimentID
+					name
+					description
+					experimentManifest
+					cronSyntax
+					isCustomExperiment
+					weightages {
+						faultName
+						weightage
+					}
+					tags
+					infra {
+						infraID
+					}
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"request": map[string]interface{}{
+			"filter": map[string]interface{}{
+				"experimentName": name,
+			},
+		},
+	}
+
+	var listExperiment ListExperimentResponse
+	if err := s.query(ctx, query, variables, "listExperiment", &listExperiment); err != nil {
+		return nil, fmt.Errorf("failed to look up experiment %s: %w", name, err)
+	}
+	for i, exp := range listExperiment.Experiments {
+		if exp.Name == name && exp.Infra != nil && exp.Infra.InfraID == infraID {
+			return &listExperiment.Experiments[i], nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,344 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+)
+
+// chaosCenterWorkflow is a workflow as Chaos Center stores it, including the fields it and Argo fill in.
+const chaosCenterWorkflow = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: checkout-pod-delete
+  namespace: litmus
+  uid: 5f0a4c1e-8d0e-4f5b-9a57-3d2b6f1c9e01
+  resourceVersion: "48213"
+  creationTimestamp: "2026-10-01T09:00:00Z"
+  generation: 3
+  labels:
+    infra_id: 1b2c3d4e
+    revision_id: 7a8b9c0d
+    workflow_id: 0f1e2d3c
+    subject: checkout-pod-delete_litmus
+    team: payments
+spec:
+  entrypoint: checkout-pod-delete
+  serviceAccountName: argo-chaos
+  securityContext:
+    runAsUser: 1000
+    runAsNonRoot: true
+  arguments:
+    parameters:
+      - name: adminModeNamespace
+        value: litmus
+  templates:
+    - name: checkout-pod-delete
+      steps:
+        - - name: install-chaos-faults
+            template: install-chaos-faults
+        - - name: pod-delete-ju1
+            template: pod-delete-ju1
+          - name: pod-network-latency-x7c
+            template: pod-network-latency-x7c
+        - - name: cleanup-chaos-resources
+            template: cleanup-chaos-resources
+    - name: install-chaos-faults
+      inputs:
+        artifacts:
+          - name: pod-delete-ju1
+            path: /tmp/pod-delete-ju1.yaml
+            raw:
+              data: |
+                apiVersion: litmuschaos.io/v1alpha1
+                kind: ChaosExperiment
+                metadata:
+                  name: pod-delete
+                spec:
+                  definition:
+                    scope: Namespaced
+                ---
+                apiVersion: litmuschaos.io/v1alpha1
+                kind: ChaosExperiment
+                metadata:
+                  name: pod-network-latency
+                spec:
+                  definition:
+                    scope: Namespaced
+      container:
+        image: litmuschaos/k8s:latest
+        command: [sh, -c]
+        args: ["kubectl apply -f /tmp/ -n {{workflow.parameters.adminModeNamespace}} && sleep 30"]
+    - name: pod-delete-ju1
+      inputs:
+        artifacts:
+          - name: pod-delete-ju1
+            path: /tmp/chaosengine-pod-delete-ju1.yaml
+            raw:
+              data: |
+                apiVersion: litmuschaos.io/v1alpha1
+                kind: ChaosEngine
+                metadata:
+                  namespace: "{{workflow.parameters.adminModeNamespace}}"
+                  labels:
+                    workflow_run_id: "{{ workflow.uid }}"
+                    workflow_name: checkout-pod-delete
+                  annotations:
+                    probeRef: '[{"name":"checkout-health","mode":"Continuous"}]'
+                  generateName: pod-delete-ju1
+                spec:
+                  engineState: active
+                  appinfo:
+                    appns: checkout
+                    applabel: app=checkout
+                    appkind: deployment
+                  chaosServiceAccount: litmus-admin
+                  experiments:
+                    - name: pod-delete
+                      spec:
+                        components:
+                          env:
+                            - name: TOTAL_CHAOS_DURATION
+                              value: "60"
+                            - name: PODS_AFFECTED_PERC
+                              value: "50"
+      container:
+        image: docker.io/litmuschaos/litmus-checker:latest
+        args: [-file=/tmp/chaosengine-pod-delete-ju1.yaml, -saveName=/tmp/engine-name]
+    - name: pod-network-latency-x7c
+      inputs:
+        artifacts:
+          - name: pod-network-latency-x7c
+            path: /tmp/chaosengine-pod-network-latency-x7c.yaml
+            raw:
+              data: |
+                apiVersion: litmuschaos.io/v1alpha1
+                kind: ChaosEngine
+                metadata:
+                  namespace: "{{workflow.parameters.adminModeNamespace}}"
+                  generateName: pod-network-latency-x7c
+                spec:
+                  engineState: active
+                  appinfo:
+                    appns: checkout
+                    applabel: app=checkout-db
+                    appkind: statefulset
+                  chaosServiceAccount: litmus-admin
+                  experiments:
+                    - name: pod-network-latency
+                      spec:
+                        components:
+                          env:
+                            - name: NETWORK_LATENCY
+                              value: "2000"
+      container:
+        image: docker.io/litmuschaos/litmus-checker:latest
+        args: [-file=/tmp/chaosengine-pod-network-latency-x7c.yaml, -saveName=/tmp/engine-name]
+    - name: cleanup-chaos-resources
+      container:
+        image: litmuschaos/k8s:latest
+        command: [sh, -c]
+        args: ["kubectl delete chaosengine -l workflow_run_id={{workflow.uid}} -n {{workflow.parameters.adminModeNamespace}}"]
+status:
+  phase: Succeeded
+  startedAt: "2026-10-01T09:00:05Z"
+`
+
+// parseManifest parses a workflow and replaces each embedded ChaosEngine with the object it describes,
+// so that manifests compare equal however their engines are formatted.
+func parseManifest(t *testing.T, text string) map[string]interface{} {
+	t.Helper()
+
+	manifest := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(text), &manifest); err != nil {
+		t.Fatalf("failed to parse manifest: %v", err)
+	}
+	for _, tmpl := range manifestTemplates(manifest) {
+		template, _ := tmpl.(map[string]interface{})
+		if raw, engine := engineArtifact(template); engine != nil {
+			raw["data"] = engine
+		}
+	}
+	return manifest
+}
+
+// withoutVolatileFields returns the workflow as export is expected to keep it.
+func withoutVolatileFields(t *testing.T, text string) map[string]interface{} {
+	t.Helper()
+
+	manifest := parseManifest(t, text)
+	delete(manifest, "status")
+	metadata := manifest["metadata"].(map[string]interface{})
+	for _, field := range []string{"namespace", "uid", "resourceVersion", "creationTimestamp", "generation"} {
+		delete(metadata, field)
+	}
+	metadata["labels"] = map[string]interface{}{"team": "payments"}
+	return manifest
+}
+
+// cronWorkflow wraps the spec of a Workflow into a CronWorkflow, as Chaos Center stores scheduled experiments.
+func cronWorkflow(t *testing.T, workflow string) string {
+	t.Helper()
+
+	manifest := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(workflow), &manifest); err != nil {
+		t.Fatal(err)
+	}
+	manifest["kind"] = "CronWorkflow"
+	manifest["spec"] = map[string]interface{}{
+		"schedule":          "0 2 * * *",
+		"concurrencyPolicy": "Forbid",
+		"timezone":          "Europe/Berlin",
+		"workflowSpec":      manifest["spec"],
+	}
+
+	// Chaos Center hands out manifests as JSON
+	text, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(text)
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+	}{
+		{name: "Workflow", manifest: chaosCenterWorkflow},
+		{name: "CronWorkflow", manifest: cronWorkflow(t, chaosCenterWorkflow)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exported, err := exportExperimentYAML(tt.manifest)
+			if err != nil {
+				t.Fatalf("export: %v", err)
+			}
+
+			docs := splitYAMLDocuments(exported)
+			if len(docs) != 3 {
+				t.Fatalf("export wrote %d documents, want the workflow and two ChaosEngines:\n%s", len(docs), exported)
+			}
+			for _, field := range []string{"uid:", "resourceVersion:", "creationTimestamp:", "revision_id:", "phase: Succeeded"} {
+				if strings.Contains(docs[0], field) {
+					t.Errorf("exported workflow still contains %q", field)
+				}
+			}
+			for i, step := range []string{"pod-delete-ju1", "pod-network-latency-x7c"} {
+				if !strings.Contains(docs[i+1], exportStepAnnotation+": "+step) {
+					t.Errorf("document %d is not annotated with step %s:\n%s", i+2, step, docs[i+1])
+				}
+			}
+
+			imported, err := importExperimentYAML(exported)
+			if err != nil {
+				t.Fatalf("import: %v", err)
+			}
+			text, err := json.Marshal(imported)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got, want := parseManifest(t, string(text)), withoutVolatileFields(t, tt.manifest); !reflect.DeepEqual(got, want) {
+				gotYAML, _ := yaml.Marshal(got)
+				wantYAML, _ := yaml.Marshal(want)
+				t.Errorf("round trip changed the manifest\ngot:\n%s\nwant:\n%s", gotYAML, wantYAML)
+			}
+		})
+	}
+}
+
+func TestImportExperimentYAMLErrors(t *testing.T) {
+	const workflow = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: checkout-pod-delete
+spec:
+  entrypoint: checkout-pod-delete
+  templates:
+    - name: pod-delete-ju1
+      inputs:
+        artifacts:
+          - name: pod-delete-ju1
+            raw: {}
+`
+	const engine = `
+apiVersion: litmuschaos.io/v1alpha1
+kind: ChaosEngine
+metadata:
+  generateName: pod-delete-ju1
+  annotations:
+    litmus-mcp-server/step: %s
+spec:
+  engineState: active
+`
+	const unannotatedEngine = `
+apiVersion: litmuschaos.io/v1alpha1
+kind: ChaosEngine
+metadata:
+  generateName: pod-delete-ju1
+spec:
+  engineState: active
+`
+	annotated := func(step string) string {
+		return fmt.Sprintf(engine, step)
+	}
+
+	tests := []struct {
+		name    string
+		text    string
+		wantErr string
+	}{
+		{
+			name:    "engine without step annotation",
+			text:    workflow + "---" + unannotatedEngine,
+			wantErr: "ChaosEngine pod-delete-ju1 has no litmus-mcp-server/step annotation",
+		},
+		{
+			name:    "empty artifact without engine",
+			text:    workflow,
+			wantErr: "step pod-delete-ju1 has no ChaosEngine",
+		},
+		{
+			name:    "engine for an unknown step",
+			text:    workflow + "---" + annotated("pod-delete-zz9"),
+			wantErr: "the workflow has no such step",
+		},
+		{
+			name:    "two engines for one step",
+			text:    workflow + "---" + annotated("pod-delete-ju1") + "---" + annotated("pod-delete-ju1"),
+			wantErr: "ChaosEngine for step pod-delete-ju1: the step has no artifact left empty",
+		},
+		{
+			name:    "no workflow",
+			text:    annotated("pod-delete-ju1"),
+			wantErr: "no Workflow or CronWorkflow document found",
+		},
+		{
+			name:    "two workflows",
+			text:    workflow + "---" + workflow,
+			wantErr: "document 2: only one Workflow or CronWorkflow is allowed",
+		},
+		{
+			name:    "other kind",
+			text:    workflow + "---\nkind: ConfigMap\n",
+			wantErr: `document 2: expected a Workflow, CronWorkflow or ChaosEngine, got kind "ConfigMap"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := importExperimentYAML(tt.text)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("import error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
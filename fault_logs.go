@@ -0,0 +1,308 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limits for get_fault_logs
+const (
+	defaultFaultLogTail    = 200
+	maxFaultLogTail        = 5000
+	defaultFaultLogTimeout = 30 * time.Second
+	maxFaultLogTimeout     = 2 * time.Minute
+)
+
+// Roles of the pods whose logs get_fault_logs returns
+const (
+	podRoleWorkflow   = "workflow"
+	podRoleExperiment = "experiment"
+	podRoleRunner     = "runner"
+)
+
+// getPodLogSubscription asks the infrastructure's subscriber for the logs of a workflow pod and, for ChaosEngine
+// steps, of the fault's experiment and runner pods. The subscriber answers once with all logs.
+const getPodLogSubscription = `
+		subscription GetPodLog($request: PodLogRequest!) {
+			getPodLog(request: $request) {
+				experimentRunID
+				podName
+				podType
+				log
+			}
+		}
+	`
+
+// podLogResponse is an event of getPodLog.
+type podLogResponse struct {
+	ExperimentRunID string `json:"experimentRunID"`
+	PodName         string `json:"podName"`
+	PodType         string `json:"podType"`
+	Log             string `json:"log"`
+}
+
+// podLogs is the log field of podLogResponse: the workflow pod's main container, and the experiment and runner
+// pods keyed by pod name.
+type podLogs struct {
+	MainLogs  string            `json:"main_logs"`
+	ChaosLogs map[string]string `json:"chaos_logs"`
+}
+
+// podLog is the log of one pod as returned by get_fault_logs.
+type podLog struct {
+	Pod        string `json:"pod"`
+	Role       string `json:"role"`
+	TotalLines int    `json:"totalLines"`
+	Lines      int    `json:"lines"`
+	Log        string `json:"log"`
+}
+
+// findFaultStep finds the ChaosEngine node of a fault by step name or fault name, returning its node ID, which is
+// also the name of the workflow pod that ran it.
+func (e *executionData) findFaultStep(fault string) (string, *executionNode, error) {
+	var ids []string
+	for id, node := range e.Nodes {
+		if node.Type != chaosEngineNodeType || node.ChaosData == nil {
+			continue
+		}
+		if node.Name == fault {
+			return id, node, nil
+		}
+		if node.ChaosData.ExperimentName == fault {
+			ids = append(ids, id)
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		faults := []string{}
+		for _, node := range e.faultNodes() {
+			faults = append(faults, fmt.Sprintf("%s (step %s)", node.ChaosData.ExperimentName, node.Name))
+		}
+		if len(faults) == 0 {
+			return "", nil, fmt.Errorf("no fault of the run has started yet")
+		}
+		return "", nil, fmt.Errorf("fault %s is not part of the run; its faults are %s", fault, strings.Join(faults, ", "))
+	case 1:
+		return ids[0], e.Nodes[ids[0]], nil
+	}
+
+	steps := make([]string, len(ids))
+	for i, id := range ids {
+		steps[i] = e.Nodes[id].Name
+	}
+	sort.Strings(steps)
+	return "", nil, fmt.Errorf("fault %s runs in several steps, name one of them: %s", fault, strings.Join(steps, ", "))
+}
+
+// unquoteLog undoes the escaping the subscriber applies to logs before sending them.
+func unquoteLog(text string) string {
+	if unquoted, err := strconv.Unquote(`"` + text + `"`); err == nil {
+		return unquoted
+	}
+	return text
+}
+
+// filterLog keeps the lines matching grep, if set, and then the last tail of them.
+func filterLog(text string, grep *regexp.Regexp, tail int) (string, int, int) {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	if text == "" {
+		lines = nil
+	}
+	total := len(lines)
+
+	if grep != nil {
+		matched := lines[:0]
+		for _, line := range lines {
+			if grep.MatchString(line) {
+				matched = append(matched, line)
+			}
+		}
+		lines = matched
+	}
+	if len(lines) > tail {
+		lines = lines[len(lines)-tail:]
+	}
+	return strings.Join(lines, "\n"), total, len(lines)
+}
+
+// fetchPodLogs runs getPodLog for one workflow step and returns the first answer of the subscriber.
+func (s *LitmusChaosServer) fetchPodLogs(ctx context.Context, request map[string]interface{}, timeout time.Duration) (*podLogs, error) {
+	client, err := newSubscriptionClient(s.config, s.projectFromContext(ctx).auth)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var response podLogResponse
+	var decodeErr error
+	received := false
+	err = client.subscribe(ctx, getPodLogSubscription, map[string]interface{}{"request": request}, func(data json.RawMessage) {
+		if received {
+			return
+		}
+		received = true
+		decodeErr = decodeField(data, "getPodLog", &response)
+		cancel()
+	})
+
+	switch {
+	case received && decodeErr != nil:
+		return nil, fmt.Errorf("malformed pod log response: %w", decodeErr)
+	case received:
+	case errors.Is(err, context.DeadlineExceeded):
+		return nil, fmt.Errorf("the infrastructure did not send logs within %s; check that it is connected", timeout)
+	case err != nil:
+		return nil, fmt.Errorf("failed to fetch pod logs: %w", err)
+	default:
+		return nil, fmt.Errorf("the subscription ended without logs")
+	}
+
+	var logs podLogs
+	if err := json.Unmarshal([]byte(response.Log), &logs); err != nil {
+		// Subscribers report failures, such as pods that no longer exist, as plain text
+		return nil, fmt.Errorf("no logs available: %s", response.Log)
+	}
+	return &logs, nil
+}
+
+// getFaultLogs returns the logs of the pods that ran a fault in an experiment run, fetched live from the pods
+// through the run's chaos infrastructure.
+func (s *LitmusChaosServer) getFaultLogs(ctx context.Context, args map[string]interface{}) (*ToolResult, error) {
+	experimentRunID := getStringFromArgs(args, "experimentRunId", "")
+	fault := getStringFromArgs(args, "fault", "")
+	if experimentRunID == "" || fault == "" {
+		return nil, fmt.Errorf("experimentRunId and fault are required")
+	}
+
+	tail := getIntFromArgs(args, "tail", defaultFaultLogTail)
+	if tail < 1 || tail > maxFaultLogTail {
+		return nil, fmt.Errorf("tail must be between 1 and %d", maxFaultLogTail)
+	}
+
+	var grep *regexp.Regexp
+	if pattern := getStringFromArgs(args, "grep", ""); pattern != "" {
+		var err error
+		if grep, err = regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("invalid grep pattern: %w", err)
+		}
+	}
+
+	pods := map[string]bool{podRoleExperiment: true}
+	if values := stringList(args, "pods"); len(values) > 0 {
+		pods = map[string]bool{}
+		for _, role := range values {
+			if role != podRoleWorkflow && role != podRoleExperiment && role != podRoleRunner {
+				return nil, fmt.Errorf("pods must be %s, %s or %s, got %q", podRoleExperiment, podRoleRunner, podRoleWorkflow, role)
+			}
+			pods[role] = true
+		}
+	}
+
+	timeout := defaultFaultLogTimeout
+	if seconds := getIntFromArgs(args, "timeoutSeconds", 0); seconds > 0 {
+		timeout = time.Duration(seconds) * time.Second
+		if timeout > maxFaultLogTimeout {
+			timeout = maxFaultLogTimeout
+		}
+	}
+
+	run, err := s.fetchExperimentRun(ctx, experimentRunID, "")
+	if err != nil {
+		return nil, err
+	}
+	if run.Infra == nil || run.Infra.InfraID == "" {
+		return nil, fmt.Errorf("experiment run %s has no infrastructure to fetch logs from", experimentRunID)
+	}
+
+	execution, err := parseExecutionData(run.ExecutionData)
+	if err != nil {
+		return nil, fmt.Errorf("experiment run %s: %w", experimentRunID, err)
+	}
+
+	nodeID, node, err := execution.findFaultStep(fault)
+	if err != nil {
+		return nil, err
+	}
+	data := node.ChaosData
+
+	namespace := execution.Namespace
+	if namespace == "" {
+		namespace = data.Namespace
+	}
+
+	request := map[string]interface{}{
+		"infraID":         run.Infra.InfraID,
+		"experimentRunID": experimentRunID,
+		"podName":         nodeID,
+		"podNamespace":    namespace,
+		"podType":         node.Type,
+		"chaosNamespace":  data.Namespace,
+	}
+	if data.ExperimentPod != "" {
+		request["expPod"] = data.ExperimentPod
+	}
+	if data.RunnerPod != "" {
+		request["runnerPod"] = data.RunnerPod
+	}
+
+	logs, err := s.fetchPodLogs(ctx, request, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []podLog{}
+	add := func(pod, role, text string) {
+		filtered, total, lines := filterLog(unquoteLog(text), grep, tail)
+		results = append(results, podLog{Pod: pod, Role: role, TotalLines: total, Lines: lines, Log: filtered})
+	}
+	if pods[podRoleExperiment] {
+		if text, ok := logs.ChaosLogs[data.ExperimentPod]; ok && data.ExperimentPod != "" {
+			add(data.ExperimentPod, podRoleExperiment, text)
+		}
+	}
+	if pods[podRoleRunner] {
+		if text, ok := logs.ChaosLogs[data.RunnerPod]; ok && data.RunnerPod != "" {
+			add(data.RunnerPod, podRoleRunner, text)
+		}
+	}
+	if pods[podRoleWorkflow] {
+		add(nodeID, podRoleWorkflow, logs.MainLogs)
+	}
+
+	response := map[string]interface{}{
+		"experimentRunId": experimentRunID,
+		"fault":           data.ExperimentName,
+		"step":            node.Name,
+		"phase":           node.Phase,
+		"verdict":         data.ExperimentVerdict,
+		"tail":            tail,
+		"logs":            results,
+	}
+	if grep != nil {
+		response["grep"] = grep.String()
+	}
+	if len(results) == 0 {
+		response["message"] = "The infrastructure returned no logs for the requested pods; the experiment pod may not have started or may have been cleaned up"
+	}
+
+	responseJSON, _ := json.MarshalIndent(response, "", "  ")
+
+	return &ToolResult{
+		Content: []ContentItem{
+			{
+				Type: "text",
+				Text: string(responseJSON),
+			},
+		},
+	}, nil
+}
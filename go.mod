@@ -3,8 +3,10 @@ module litmuschaos-mcp-server
 go 1.21
 
 require (
-	github.com/gorilla/websocket v1.5.1 // indirect for future WebSocket support
+	github.com/gorilla/websocket v1.5.1 // GraphQL subscriptions over WebSocket
 	github.com/json-iterator/go v1.1.12 // high-performance JSON processing
 	github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd // indirect
 	github.com/modern-go/reflect2 v1.0.2 // indirect
+	golang.org/x/net v0.17.0 // indirect
+	sigs.k8s.io/yaml v1.4.0 // YAML rendering for chaos workflow manifests
 )
\ No newline at end of file
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Environment type Chaos Center uses for production environments
+const prodEnvironmentType = "PROD"
+
+const defaultConfirmationTTL = 5 * time.Minute
+
+// pendingConfirmation is a PROD run or schedule that was previewed and waits to be confirmed with its token.
+type pendingConfirmation struct {
+	experimentID string
+	projectID    string
+	updatedAt    string
+	sessionID    string
+	expiresAt    time.Time
+}
+
+// confirmationStore hands out single-use tokens that confirm previewed PROD runs and schedules.
+type confirmationStore struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	pending map[string]*pendingConfirmation
+}
+
+func newConfirmationStore(ttl time.Duration) *confirmationStore {
+	return &confirmationStore{
+		ttl:     ttl,
+		pending: make(map[string]*pendingConfirmation),
+	}
+}
+
+// issue stores a confirmation and returns its token.
+func (c *confirmationStore) issue(confirmation *pendingConfirmation) string {
+	buf := make([]byte, 16)
+	rand.Read(buf)
+	token := hex.EncodeToString(buf)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for t, p := range c.pending {
+		if now.After(p.expiresAt) {
+			delete(c.pending, t)
+		}
+	}
+
+	confirmation.expiresAt = now.Add(c.ttl)
+	c.pending[token] = confirmation
+	return token
+}
+
+// redeem consumes a token. It fails if the token is unknown, expired, issued to another session, or was issued
+// for a different experiment, project or version of the experiment than the one about to run.
+func (c *confirmationStore) redeem(token string, want *pendingConfirmation) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	p, ok := c.pending[token]
+	if !ok {
+		return fmt.Errorf("confirmation token is unknown or was already used; call again without confirmationToken for a new preview")
+	}
+	delete(c.pending, token)
+
+	switch {
+	case time.Now().After(p.expiresAt):
+		return fmt.Errorf("confirmation token expired at %s; call again without confirmationToken for a new preview", p.expiresAt.Format(time.RFC3339))
+	case p.experimentID != want.experimentID || p.projectID != want.projectID:
+		return fmt.Errorf("confirmation token was issued for experiment %s in project %s", p.experimentID, p.projectID)
+	case p.sessionID != want.sessionID:
+		return fmt.Errorf("confirmation token was issued to a different client session")
+	case p.updatedAt != want.updatedAt:
+		return fmt.Errorf("experiment %s changed after the preview; call again without confirmationToken for a new preview", p.experimentID)
+	}
+	return nil
+}
+
+// fetchEnvironment looks up an environment through listEnvironments.
+func (s *LitmusChaosServer) fetchEnvironment(ctx context.Context, environmentID string) (*Environment, error) {
+	query := `
+		query ListEnvironments($projectID: ID!, $request: ListEnvironmentRequest) {
+			listEnvironments(projectID: $projectID, request: $request) {
+				totalNoOfEnvironments
+				environments {
+					environmentID
+					name
+					type
+					tags
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"request": map[string]interface{}{
+			"environmentIDs": []string{environmentID},
+		},
+	}
+
+	var listEnvs ListEnvironmentResponse
+	if err := s.query(ctx, query, variables, "listEnvironments", &listEnvs); err != nil {
+		return nil, fmt.Errorf("failed to fetch environment %s: %w", environmentID, err)
+	}
+	for i := range listEnvs.Environments {
+		if listEnvs.Environments[i].EnvironmentID == environmentID {
+			return &listEnvs.Environments[i], nil
+		}
+	}
+	return nil, fmt.Errorf("environment %s not found", environmentID)
+}
+
+// admitRun runs the checks that precede starting an experiment: blackout windows, the admission policy, then the
+// PROD confirmation. A non-nil result is a denial or preview to return instead of starting the run; otherwise the
+// run may start, and the returned findings are admission policy warnings to report with it.
+func (s *LitmusChaosServer) admitRun(ctx context.Context, experimentID, confirmationToken string) ([]policyFinding, *ToolResult, error) {
+	if s.blackouts == nil && s.admission == nil && !s.config.ProdGuardrail {
+		return nil, nil, nil
+	}
+
+	getExperiment, err := s.fetchExperiment(ctx, experimentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	exp := getExperiment.ExperimentDetails
+
+	env, err := s.infraEnvironment(ctx, exp.Infra)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot tell which environment experiment %s targets: %w", experimentID, err)
+	}
+
+	if err := s.checkBlackout(exp.Infra, env); err != nil {
+		return nil, nil, fmt.Errorf("experiment %s cannot run now: %w", exp.Name, err)
+	}
+
+	findings, denial, err := s.admitManifest(exp.ExperimentManifest, exp.Name, "running", exp.Infra, env)
+	if err != nil || denial != nil {
+		return nil, denial, err
+	}
+
+	preview, err := s.guardProdRun(ctx, exp, env, findings, confirmationToken, "start the run")
+	return findings, preview, err
+}
+
+// guardProdRun decides whether an experiment on infrastructure in a PROD environment may start. Such runs need
+// a confirmation token from an earlier preview; without one, guardProdRun returns the preview to show instead.
+// action completes the preview's message, as in "call again with confirmationToken to start the run".
+func (s *LitmusChaosServer) guardProdRun(ctx context.Context, exp *Experiment, env *Environment, findings []policyFinding, confirmationToken, action string) (*ToolResult, error) {
+	if !s.config.ProdGuardrail || env == nil || env.Type != prodEnvironmentType {
+		return nil, nil
+	}
+
+	// Experiments that do not exist yet are confirmed by name, as each call gives them a new ID
+	key := exp.ExperimentID
+	if key == "" {
+		key = exp.Name
+	}
+
+	confirmation := &pendingConfirmation{
+		experimentID: key,
+		projectID:    s.projectFromContext(ctx).ID,
+		updatedAt:    exp.UpdatedAt,
+	}
+	if session := sessionFromContext(ctx); session != nil {
+		confirmation.sessionID = session.id
+	}
+
+	if confirmationToken != "" {
+		return nil, s.confirmations.redeem(confirmationToken, confirmation)
+	}
+
+	faults := make([]map[string]interface{}, len(exp.Weightages))
+	for i, weight := range exp.Weightages {
+		faults[i] = map[string]interface{}{
+			"name":   weight.FaultName,
+			"weight": weight.Weightage,
+		}
+	}
+
+	preview := map[string]interface{}{
+		"experiment": map[string]interface{}{
+			"id":       exp.ExperimentID,
+			"name":     exp.Name,
+			"schedule": exp.CronSyntax,
+			"faults":   faults,
+		},
+		"infrastructure": map[string]interface{}{
+			"id":        exp.Infra.InfraID,
+			"name":      exp.Infra.Name,
+			"scope":     exp.Infra.InfraScope,
+			"platform":  exp.Infra.PlatformName,
+			"active":    exp.Infra.IsActive,
+			"namespace": exp.Infra.InfraNamespace,
+		},
+		"environment": map[string]interface{}{
+			"id":   env.EnvironmentID,
+			"name": env.Name,
+			"type": env.Type,
+			"tags": env.Tags,
+		},
+	}
+
+	if manifest, err := parseExperimentManifest(exp.ExperimentManifest); err != nil {
+		preview["blastRadius"] = map[string]interface{}{
+			"error": fmt.Sprintf("could not read the experiment manifest, review it with get_chaos_experiment: %v", err),
+		}
+	} else {
+		preview["blastRadius"] = manifest.blastRadius()
+	}
+	if len(findings) > 0 {
+		preview["policyFindings"] = findings
+	}
+
+	token := s.confirmations.issue(confirmation)
+
+	response := map[string]interface{}{
+		"status":            "confirmation_required",
+		"message":           fmt.Sprintf("Experiment %s targets infrastructure %s in PROD environment %s. Review the preview with the user, then call again with confirmationToken to %s.", exp.Name, exp.Infra.Name, env.Name, action),
+		"confirmationToken": token,
+		"expiresAt":         confirmation.expiresAt.Format(time.RFC3339),
+		"preview":           preview,
+	}
+
+	responseJSON, _ := json.MarshalIndent(response, "", "  ")
+
+	return &ToolResult{
+		Content: []ContentItem{
+			{
+				Type: "text",
+				Text: string(responseJSON),
+			},
+		},
+	}, nil
+}
+
+// guardProdSchedule is guardProdRun for calls that save or enable a schedule on infrastructure in a PROD
+// environment, which starts recurring runs without a further call. exp is the experiment as it will be scheduled,
+// without an ID if it does not exist yet. Besides the experiment, the token is bound to the schedule,
+// infrastructure and faults, so it cannot confirm a different schedule than the one previewed.
+func (s *LitmusChaosServer) guardProdSchedule(ctx context.Context, exp *Experiment, env *Environment, findings []policyFinding, confirmationToken, action string) (*ToolResult, error) {
+	fingerprint := sha256.New()
+	fmt.Fprintf(fingerprint, "%s\n", exp.CronSyntax)
+	if exp.Infra != nil {
+		fmt.Fprintf(fingerprint, "%s\n", exp.Infra.InfraID)
+	}
+	if manifest, err := parseExperimentManifest(exp.ExperimentManifest); err == nil {
+		faults, _ := json.Marshal(manifest.Faults)
+		fingerprint.Write(faults)
+	} else {
+		fingerprint.Write([]byte(exp.ExperimentManifest))
+	}
+
+	scheduled := *exp
+	scheduled.UpdatedAt = exp.UpdatedAt + "/" + hex.EncodeToString(fingerprint.Sum(nil))
+	return s.guardProdRun(ctx, &scheduled, env, findings, confirmationToken, action)
+}
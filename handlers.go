@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 )
 
 // Utility functions for type conversion
@@ -82,9 +84,8 @@ func getNestedString(obj map[string]interface{}, keys ...string) string {
 
 // Tool handler implementations
 
-// listChaosExperiments queries ChaosCenter for experiments with optional pagination and filters and returns a formatted summary.
-func (s *LitmusChaosServer) listChaosExperiments(ctx context.Context, args map[string]interface{}) (*ToolResult, error) {
-	query := `
+// listExperimentsQuery lists experiments with their infrastructure and latest runs.
+const listExperimentsQuery = `
 		query ListExperiment($projectID: ID!, $request: ListExperimentRequest!) {
 			listExperiment(projectID: $projectID, request: $request) {
 				totalNoOfExperiments
@@ -123,6 +124,8 @@ func (s *LitmusChaosServer) listChaosExperiments(ctx context.Context, args map[s
 		}
 	`
 
+// listChaosExperiments queries ChaosCenter for experiments with optional pagination and filters and returns a formatted summary.
+func (s *LitmusChaosServer) listChaosExperiments(ctx context.Context, args map[string]interface{}) (*ToolResult, error) {
 	request := map[string]interface{}{
 		"pagination": map[string]interface{}{
 			"page":  getIntFromArgs(getMapFromArgs(args, "pagination"), "page", 0),
@@ -138,70 +141,56 @@ func (s *LitmusChaosServer) listChaosExperiments(ctx context.Context, args map[s
 		"request": request,
 	}
 
-	data, err := s.graphqlRequest(ctx, query, variables)
-	if err != nil {
+	var listExperiment ListExperimentResponse
+	if err := s.query(ctx, listExperimentsQuery, variables, "listExperiment", &listExperiment); err != nil {
 		return nil, err
 	}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(data, &result); err != nil {
-		return nil, err
-	}
-
-	listExperiment := result["listExperiment"].(map[string]interface{})
-	experiments := listExperiment["experiments"].([]interface{})
-
-	formattedExperiments := make([]map[string]interface{}, len(experiments))
-	for i, exp := range experiments {
-		experiment := exp.(map[string]interface{})
-		
+	formattedExperiments := make([]map[string]interface{}, len(listExperiment.Experiments))
+	for i, experiment := range listExperiment.Experiments {
 		var infrastructure map[string]interface{}
-		if infra := experiment["infra"]; infra != nil {
-			infraMap := infra.(map[string]interface{})
+		if infra := experiment.Infra; infra != nil {
 			infrastructure = map[string]interface{}{
-				"id":          infraMap["infraID"],
-				"name":        infraMap["name"],
-				"environment": infraMap["environmentID"],
-				"active":      infraMap["isActive"],
-				"confirmed":   infraMap["isInfraConfirmed"],
-				"platform":    infraMap["platformName"],
+				"id":          infra.InfraID,
+				"name":        infra.Name,
+				"environment": infra.EnvironmentID,
+				"active":      infra.IsActive,
+				"confirmed":   infra.IsInfraConfirmed,
+				"platform":    infra.PlatformName,
 			}
 		}
 
 		var recentRun map[string]interface{}
-		if runs := experiment["recentExperimentRunDetails"]; runs != nil {
-			runsSlice := runs.([]interface{})
-			if len(runsSlice) > 0 {
-				run := runsSlice[0].(map[string]interface{})
-				recentRun = map[string]interface{}{
-					"id":             run["experimentRunID"],
-					"status":         run["phase"],
-					"resiliencyScore": run["resiliencyScore"],
-					"lastRun":        run["updatedAt"],
-					"sequence":       run["runSequence"],
-				}
+		if len(experiment.RecentExperimentRunDetails) > 0 {
+			run := experiment.RecentExperimentRunDetails[0]
+			recentRun = map[string]interface{}{
+				"id":              run.ExperimentRunID,
+				"status":          run.Phase,
+				"resiliencyScore": run.ResiliencyScore,
+				"lastRun":         run.UpdatedAt,
+				"sequence":        run.RunSequence,
 			}
 		}
 
 		formattedExperiments[i] = map[string]interface{}{
-			"id":           experiment["experimentID"],
-			"name":         experiment["name"],
-			"description":  experiment["description"],
-			"type":         experiment["experimentType"],
-			"isCustom":     experiment["isCustomExperiment"],
-			"schedule":     experiment["cronSyntax"],
-			"tags":         experiment["tags"],
+			"id":             experiment.ExperimentID,
+			"name":           experiment.Name,
+			"description":    experiment.Description,
+			"type":           experiment.ExperimentType,
+			"isCustom":       experiment.IsCustomExperiment,
+			"schedule":       experiment.CronSyntax,
+			"tags":           experiment.Tags,
 			"infrastructure": infrastructure,
-			"recentRun":    recentRun,
-			"createdBy":    getNestedString(experiment, "createdBy", "username"),
-			"createdAt":    experiment["createdAt"],
-			"updatedAt":    experiment["updatedAt"],
+			"recentRun":      recentRun,
+			"createdBy":      experiment.CreatedBy.name(),
+			"createdAt":      experiment.CreatedAt,
+			"updatedAt":      experiment.UpdatedAt,
 		}
 	}
 
 	response := map[string]interface{}{
-		"summary":          fmt.Sprintf("Found %v chaos experiments", listExperiment["totalNoOfExperiments"]),
-		"totalExperiments": listExperiment["totalNoOfExperiments"],
+		"summary":          fmt.Sprintf("Found %d chaos experiments", listExperiment.TotalNoOfExperiments),
+		"totalExperiments": listExperiment.TotalNoOfExperiments,
 		"experiments":      formattedExperiments,
 	}
 
@@ -217,9 +206,8 @@ func (s *LitmusChaosServer) listChaosExperiments(ctx context.Context, args map[s
 	}, nil
 }
 
-// getChaosExperiment fetches detailed information for a specific chaos experiment identified by experimentId.
-func (s *LitmusChaosServer) getChaosExperiment(ctx context.Context, args map[string]interface{}) (*ToolResult, error) {
-	query := `
+// getExperimentQuery fetches a single experiment with its manifest, weightages and infrastructure.
+const getExperimentQuery = `
 		query GetExperiment($projectID: ID!, $experimentID: String!) {
 			getExperiment(projectID: $projectID, experimentID: $experimentID) {
 				experimentDetails {
@@ -244,7 +232,9 @@ func (s *LitmusChaosServer) getChaosExperiment(ctx context.Context, args map[str
 						platformName
 						isActive
 						infraScope
+						infraNamespace
 						version
+						tags
 						noOfExperiments
 						noOfExperimentRuns
 					}
@@ -264,78 +254,88 @@ func (s *LitmusChaosServer) getChaosExperiment(ctx context.Context, args map[str
 		}
 	`
 
-	experimentID := getStringFromArgs(args, "experimentId", "")
-	if experimentID == "" {
-		return nil, fmt.Errorf("experimentId is required")
-	}
-
+// fetchExperiment runs getExperimentQuery and returns the getExperiment payload.
+func (s *LitmusChaosServer) fetchExperiment(ctx context.Context, experimentID string) (*GetExperimentResponse, error) {
 	variables := map[string]interface{}{
 		"experimentID": experimentID,
 	}
 
-	data, err := s.graphqlRequest(ctx, query, variables)
-	if err != nil {
-		return nil, err
+	var getExperiment GetExperimentResponse
+	if err := s.query(ctx, getExperimentQuery, variables, "getExperiment", &getExperiment); err != nil {
+		return nil, fmt.Errorf("failed to fetch experiment %s: %w", experimentID, err)
 	}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(data, &result); err != nil {
-		return nil, err
-	}
+	return &getExperiment, nil
+}
 
-	getExperiment := result["getExperiment"].(map[string]interface{})
-	exp := getExperiment["experimentDetails"].(map[string]interface{})
+// formatExperimentDetails shapes a getExperiment payload into the get_chaos_experiment response.
+func formatExperimentDetails(getExperiment *GetExperimentResponse) map[string]interface{} {
+	exp := getExperiment.ExperimentDetails
 
-	var faults []map[string]interface{}
-	if weightages := exp["weightages"]; weightages != nil {
-		weightagesSlice := weightages.([]interface{})
-		faults = make([]map[string]interface{}, len(weightagesSlice))
-		for i, w := range weightagesSlice {
-			weight := w.(map[string]interface{})
-			faults[i] = map[string]interface{}{
-				"name":   weight["faultName"],
-				"weight": weight["weightage"],
-			}
+	faults := make([]map[string]interface{}, len(exp.Weightages))
+	for i, weight := range exp.Weightages {
+		faults[i] = map[string]interface{}{
+			"name":   weight.FaultName,
+			"weight": weight.Weightage,
 		}
 	}
 
 	infrastructure := map[string]interface{}{}
-	if infra := exp["infra"]; infra != nil {
-		infraMap := infra.(map[string]interface{})
+	if infra := exp.Infra; infra != nil {
 		infrastructure = map[string]interface{}{
-			"id":               infraMap["infraID"],
-			"name":             infraMap["name"],
-			"description":      infraMap["description"],
-			"environment":      infraMap["environmentID"],
-			"platform":         infraMap["platformName"],
-			"active":           infraMap["isActive"],
-			"scope":            infraMap["infraScope"],
-			"version":          infraMap["version"],
-			"totalExperiments": infraMap["noOfExperiments"],
-			"totalRuns":        infraMap["noOfExperimentRuns"],
-		}
+			"id":               infra.InfraID,
+			"name":             infra.Name,
+			"description":      infra.Description,
+			"environment":      infra.EnvironmentID,
+			"platform":         infra.PlatformName,
+			"active":           infra.IsActive,
+			"scope":            infra.InfraScope,
+			"version":          infra.Version,
+			"totalExperiments": infra.NoOfExperiments,
+			"totalRuns":        infra.NoOfExperimentRuns,
+		}
+	}
+
+	experiment := map[string]interface{}{
+		"id":                     exp.ExperimentID,
+		"name":                   exp.Name,
+		"description":            exp.Description,
+		"type":                   exp.ExperimentType,
+		"isCustom":               exp.IsCustomExperiment,
+		"schedule":               exp.CronSyntax,
+		"manifest":               exp.ExperimentManifest,
+		"averageResiliencyScore": getExperiment.AverageResiliencyScore,
+		"faults":                 faults,
+		"tags":                   exp.Tags,
+		"infrastructure":         infrastructure,
+		"createdBy":              exp.CreatedBy.name(),
+		"updatedBy":              exp.UpdatedBy.name(),
+		"createdAt":              exp.CreatedAt,
+		"updatedAt":              exp.UpdatedAt,
+	}
+	if exp.CronSyntax != "" {
+		experiment["scheduleEnabled"] = !scheduleSuspended(exp.ExperimentManifest)
+	}
+
+	return map[string]interface{}{
+		"experiment": experiment,
 	}
+}
 
-	response := map[string]interface{}{
-		"experiment": map[string]interface{}{
-			"id":                     exp["experimentID"],
-			"name":                   exp["name"],
-			"description":            exp["description"],
-			"type":                   exp["experimentType"],
-			"isCustom":               exp["isCustomExperiment"],
-			"schedule":               exp["cronSyntax"],
-			"manifest":               exp["experimentManifest"],
-			"averageResiliencyScore": getExperiment["averageResiliencyScore"],
-			"faults":                 faults,
-			"tags":                   exp["tags"],
-			"infrastructure":         infrastructure,
-			"createdBy":              getNestedString(exp, "createdBy", "username"),
-			"updatedBy":              getNestedString(exp, "updatedBy", "username"),
-			"createdAt":              exp["createdAt"],
-			"updatedAt":              exp["updatedAt"],
-		},
+// getChaosExperiment fetches detailed information for a specific chaos experiment identified by experimentId.
+func (s *LitmusChaosServer) getChaosExperiment(ctx context.Context, args map[string]interface{}) (*ToolResult, error) {
+	experimentID := getStringFromArgs(args, "experimentId", "")
+	if experimentID == "" {
+		return nil, fmt.Errorf("experimentId is required")
 	}
 
+	getExperiment, err := s.fetchExperiment(ctx, experimentID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := formatExperimentDetails(getExperiment)
+
 	responseJSON, _ := json.MarshalIndent(response, "", "  ")
 
 	return &ToolResult{
@@ -348,107 +348,120 @@ func (s *LitmusChaosServer) getChaosExperiment(ctx context.Context, args map[str
 	}, nil
 }
 
-// TODO: not being used in the main.go as a valid tool until further refinement for manifest accuracy
-// createChaosExperiment creates a new chaos experiment manifest from provided faults and submits it to ChaosCenter.
+// createChaosExperiment builds a Chaos Center experiment workflow from ChaosHub fault definitions and submits it to ChaosCenter.
 func (s *LitmusChaosServer) createChaosExperiment(ctx context.Context, args map[string]interface{}) (*ToolResult, error) {
 	name := getStringFromArgs(args, "name", "")
 	if name == "" {
 		return nil, fmt.Errorf("name is required")
 	}
 
-	faults := getSliceFromArgs(args, "faults")
-	if len(faults) == 0 {
+	workflowName := sanitizeResourceName(name)
+	if workflowName == "" {
+		return nil, fmt.Errorf("name must contain at least one alphanumeric character")
+	}
+
+	faultArgs := getSliceFromArgs(args, "faults")
+	if len(faultArgs) == 0 {
 		return nil, fmt.Errorf("at least one fault is required")
 	}
 
+	faults, err := parseFaultSpecs(faultArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	weightages, err := buildWeightages(faults)
+	if err != nil {
+		return nil, err
+	}
+
 	infraId := getStringFromArgs(args, "infraId", s.config.DefaultInfraID)
 	if infraId == "" {
 		return nil, fmt.Errorf("infraId is required")
 	}
 
-	// Build fault manifests
-	faultManifests := make([]map[string]interface{}, len(faults))
-	weightages := make([]map[string]interface{}, len(faults))
+	infra, err := s.fetchInfra(ctx, infraId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch infrastructure %s: %w", infraId, err)
+	}
 
-	for i, fault := range faults {
-		faultMap := fault.(map[string]interface{})
-		faultName := getStringFromArgs(faultMap, "name", "")
-		weight := getIntFromArgs(faultMap, "weight", 5)
-		targetApp := getStringFromArgs(faultMap, "targetApp", "")
-		duration := getStringFromArgs(faultMap, "duration", "60")
-		parameters := getMapFromArgs(faultMap, "parameters")
+	namespace := infra.InfraNamespace
+	if namespace == "" {
+		namespace = "litmus"
+	}
+	version := infra.Version
+	if version == "" {
+		version = defaultLitmusVersion
+	}
 
-		env := []map[string]interface{}{
-			{"name": "TOTAL_CHAOS_DURATION", "value": duration},
-			{"name": "TARGET_PODS", "value": targetApp},
+	hubID := getStringFromArgs(args, "hubId", "")
+	if hubID == "" {
+		hubID, err = s.defaultChaosHubID(ctx)
+		if err != nil {
+			return nil, err
 		}
+	}
 
-		if parameters != nil {
-			for key, value := range parameters {
-				env = append(env, map[string]interface{}{
-					"name":  strings.ToUpper(key),
-					"value": fmt.Sprintf("%v", value),
-				})
-			}
-		}
+	defs, err := s.fetchHubFaults(ctx, hubID, faults)
+	if err != nil {
+		return nil, err
+	}
 
-		faultManifests[i] = map[string]interface{}{
-			"name":   faultName,
-			"weight": weight,
-			"spec": map[string]interface{}{
-				"components": map[string]interface{}{
-					"env": env,
-				},
-			},
-		}
+	schedule := ""
+	if scheduleMap := getMapFromArgs(args, "schedule"); scheduleMap != nil {
+		schedule = getStringFromArgs(scheduleMap, "cronExpression", "")
+	}
 
-		weightages[i] = map[string]interface{}{
-			"faultName":  faultName,
-			"weightage":  weight,
+	var env *Environment
+	if schedule != "" || s.admission != nil {
+		env, err = s.infraEnvironment(ctx, infra)
+		if err != nil {
+			return nil, fmt.Errorf("cannot tell which environment infrastructure %s belongs to: %w", infraId, err)
 		}
 	}
-
-	// Create workflow manifest
-	workflowSteps := make([]map[string]interface{}, len(faultManifests))
-	templates := make([]map[string]interface{}, len(faultManifests)+1)
-
-	for i, fault := range faultManifests {
-		faultName := fault["name"].(string)
-		workflowSteps[i] = map[string]interface{}{
-			"name":     faultName,
-			"template": faultName,
+	if schedule != "" {
+		if err := s.checkBlackout(infra, env); err != nil {
+			return nil, fmt.Errorf("experiment %s cannot be scheduled now: %w", workflowName, err)
 		}
+	}
 
-		templates[i+1] = map[string]interface{}{
-			"name": faultName,
-			"container": map[string]interface{}{
-				"image":   "litmuschaos/go-runner:latest",
-				"command": []string{"chaos-runner"},
-				"args":    []string{"-name", faultName},
-				"env":     fault["spec"].(map[string]interface{})["components"].(map[string]interface{})["env"],
-			},
-		}
+	experimentID := newUUID()
+	manifest, err := buildChaosWorkflow(workflowOptions{
+		Name:         workflowName,
+		ExperimentID: experimentID,
+		InfraID:      infraId,
+		Namespace:    namespace,
+		Version:      version,
+		CronSyntax:   schedule,
+		Parallel:     getBoolFromArgs(args, "parallel", false),
+	}, faults, defs)
+	if err != nil {
+		return nil, err
 	}
 
-	templates[0] = map[string]interface{}{
-		"name": "chaos-experiment",
-		"steps": [][]map[string]interface{}{workflowSteps},
+	manifestJSON, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal experiment manifest: %w", err)
 	}
 
-	manifest := map[string]interface{}{
-		"apiVersion": "argoproj.io/v1alpha1",
-		"kind":       "Workflow",
-		"metadata": map[string]interface{}{
-			"name":      strings.ToLower(strings.ReplaceAll(name, " ", "-")),
-			"namespace": "litmus",
-		},
-		"spec": map[string]interface{}{
-			"entrypoint": "chaos-experiment",
-			"templates":  templates,
-		},
+	findings, denial, err := s.admitManifest(string(manifestJSON), workflowName, "creating", infra, env)
+	if err != nil || denial != nil {
+		return denial, err
 	}
 
-	manifestJSON, _ := json.Marshal(manifest)
+	if schedule != "" {
+		exp := &Experiment{
+			Name:               workflowName,
+			ExperimentManifest: string(manifestJSON),
+			CronSyntax:         schedule,
+			Weightages:         weightages,
+			Infra:              infra,
+		}
+		preview, err := s.guardProdSchedule(ctx, exp, env, findings, getStringFromArgs(args, "confirmationToken", ""), "create it with its schedule")
+		if err != nil || preview != nil {
+			return preview, err
+		}
+	}
 
 	mutation := `
 		mutation CreateChaosExperiment($request: ChaosExperimentRequest!, $projectID: ID!) {
@@ -463,13 +476,6 @@ func (s *LitmusChaosServer) createChaosExperiment(ctx context.Context, args map[
 		}
 	`
 
-	schedule := "none"
-	if scheduleMap := getMapFromArgs(args, "schedule"); scheduleMap != nil {
-		if cronExpr := getStringFromArgs(scheduleMap, "cronExpression", ""); cronExpr != "" {
-			schedule = cronExpr
-		}
-	}
-
 	tags := []string{}
 	if tagsSlice := getSliceFromArgs(args, "tags"); tagsSlice != nil {
 		tags = make([]string, len(tagsSlice))
@@ -479,7 +485,8 @@ func (s *LitmusChaosServer) createChaosExperiment(ctx context.Context, args map[
 	}
 
 	request := map[string]interface{}{
-		"experimentName":        name,
+		"experimentID":          experimentID,
+		"experimentName":        workflowName,
 		"experimentDescription": getStringFromArgs(args, "description", "Created via MCP Server"),
 		"infraID":               infraId,
 		"experimentManifest":    string(manifestJSON),
@@ -493,36 +500,40 @@ func (s *LitmusChaosServer) createChaosExperiment(ctx context.Context, args map[
 		"request": request,
 	}
 
-	data, err := s.graphqlRequest(ctx, mutation, variables)
-	if err != nil {
-		return nil, err
-	}
-
-	var result map[string]interface{}
-	if err := json.Unmarshal(data, &result); err != nil {
+	var createResult ChaosExperimentResponse
+	if err := s.query(ctx, mutation, variables, "createChaosExperiment", &createResult); err != nil {
 		return nil, err
 	}
 
-	createResult := result["createChaosExperiment"].(map[string]interface{})
-
-	faultNames := make([]string, len(faultManifests))
-	for i, f := range faultManifests {
-		faultNames[i] = f["name"].(string)
+	steps := stepNames(faults)
+	faultSummaries := make([]map[string]interface{}, len(faults))
+	for i, f := range faults {
+		faultSummaries[i] = map[string]interface{}{
+			"step":   steps[i],
+			"name":   f.Name,
+			"weight": f.Weight,
+			"probes": f.Probes,
+		}
 	}
 
 	response := map[string]interface{}{
 		"success": true,
-		"message": fmt.Sprintf("Chaos experiment '%s' created successfully", name),
+		"message": fmt.Sprintf("Chaos experiment '%s' created successfully", workflowName),
 		"experiment": map[string]interface{}{
-			"id":          createResult["experimentID"],
-			"name":        createResult["experimentName"],
-			"description": createResult["experimentDescription"],
-			"schedule":    createResult["cronSyntax"],
-			"isCustom":    createResult["isCustomExperiment"],
-			"tags":        createResult["tags"],
-			"faults":      faultNames,
+			"id":             createResult.ExperimentID,
+			"name":           createResult.ExperimentName,
+			"description":    createResult.ExperimentDescription,
+			"schedule":       createResult.CronSyntax,
+			"isCustom":       createResult.IsCustomExperiment,
+			"tags":           createResult.Tags,
+			"infrastructure": infraId,
+			"hubId":          hubID,
+			"faults":         faultSummaries,
 		},
 	}
+	if len(findings) > 0 {
+		response["policyFindings"] = findings
+	}
 
 	responseJSON, _ := json.MarshalIndent(response, "", "  ")
 
@@ -536,13 +547,136 @@ func (s *LitmusChaosServer) createChaosExperiment(ctx context.Context, args map[
 	}, nil
 }
 
-// runChaosExperiment triggers execution of a chaos experiment by its experimentId.
-func (s *LitmusChaosServer) runChaosExperiment(ctx context.Context, args map[string]interface{}) (*ToolResult, error) {
-	experimentID := getStringFromArgs(args, "experimentId", "")
-	if experimentID == "" {
-		return nil, fmt.Errorf("experimentId is required")
+// defaultChaosHubID returns the ID of the project's default ChaosHub.
+func (s *LitmusChaosServer) defaultChaosHubID(ctx context.Context) (string, error) {
+	query := `
+		query ListChaosHub($projectID: ID!) {
+			listChaosHub(projectID: $projectID) {
+				id
+				name
+				isDefault
+			}
+		}
+	`
+
+	var hubs ChaosHubList
+	if err := s.query(ctx, query, nil, "listChaosHub", &hubs); err != nil {
+		return "", err
+	}
+
+	for _, hub := range hubs {
+		if hub.IsDefault {
+			return hub.ID, nil
+		}
+	}
+	if len(hubs) > 0 {
+		return hubs[0].ID, nil
+	}
+
+	return "", fmt.Errorf("no ChaosHub found in project; pass hubId explicitly")
+}
+
+// getChaosFaultQuery fetches a fault's ChaosExperiment and sample ChaosEngine definitions from a ChaosHub.
+const getChaosFaultQuery = `
+		query GetChaosFault($projectID: ID!, $request: GetChaosFaultRequest!) {
+			getChaosFault(projectID: $projectID, request: $request) {
+				fault
+				engine
+			}
+		}
+	`
+
+// fetchHubFault runs getChaosFaultQuery for a single fault.
+func (s *LitmusChaosServer) fetchHubFault(ctx context.Context, hubID, category, faultName string) (hubFault, error) {
+	variables := map[string]interface{}{
+		"request": map[string]interface{}{
+			"hubID":          hubID,
+			"category":       category,
+			"experimentName": faultName,
+		},
 	}
 
+	var def hubFault
+	if err := s.query(ctx, getChaosFaultQuery, variables, "getChaosFault", &def); err != nil {
+		return hubFault{}, fmt.Errorf("failed to fetch fault %s/%s from ChaosHub: %w", category, faultName, err)
+	}
+	if def.Fault == "" {
+		return hubFault{}, fmt.Errorf("ChaosHub returned no definition for fault %s/%s", category, faultName)
+	}
+
+	return def, nil
+}
+
+// fetchHubFaults loads the ChaosExperiment and sample ChaosEngine definitions for each distinct fault from a ChaosHub,
+// resolving fault categories from the hub listing when they are not given.
+func (s *LitmusChaosServer) fetchHubFaults(ctx context.Context, hubID string, faults []faultSpec) (map[string]hubFault, error) {
+	var categories map[string]string
+
+	defs := map[string]hubFault{}
+	for _, fault := range faults {
+		if _, ok := defs[fault.Name]; ok {
+			continue
+		}
+
+		category := fault.Category
+		if category == "" {
+			if categories == nil {
+				var err error
+				categories, err = s.hubFaultCategories(ctx, hubID)
+				if err != nil {
+					return nil, err
+				}
+			}
+			category = categories[fault.Name]
+			if category == "" {
+				return nil, fmt.Errorf("fault %s not found in ChaosHub %s", fault.Name, hubID)
+			}
+		}
+
+		def, err := s.fetchHubFault(ctx, hubID, category, fault.Name)
+		if err != nil {
+			return nil, err
+		}
+		defs[fault.Name] = def
+	}
+
+	return defs, nil
+}
+
+// hubFaultCategories maps each fault name in a ChaosHub to its category.
+func (s *LitmusChaosServer) hubFaultCategories(ctx context.Context, hubID string) (map[string]string, error) {
+	query := `
+		query ListChaosFaults($hubID: ID!, $projectID: ID!) {
+			listChaosFaults(hubID: $hubID, projectID: $projectID) {
+				metadata {
+					name
+				}
+				spec {
+					faults {
+						name
+					}
+				}
+			}
+		}
+	`
+
+	var faultCategories FaultCategoryList
+	if err := s.query(ctx, query, map[string]interface{}{"hubID": hubID}, "listChaosFaults", &faultCategories); err != nil {
+		return nil, err
+	}
+
+	categories := map[string]string{}
+	for _, category := range faultCategories {
+		for _, fault := range category.Spec.Faults {
+			categories[fault.Name] = category.Metadata.Name
+		}
+	}
+
+	return categories, nil
+}
+
+// startExperimentRun triggers a run of the experiment and returns the notify ID that identifies it until it is scheduled.
+func (s *LitmusChaosServer) startExperimentRun(ctx context.Context, experimentID string) (string, error) {
 	mutation := `
 		mutation RunChaosExperiment($experimentID: String!, $projectID: ID!) {
 			runChaosExperiment(experimentID: $experimentID, projectID: $projectID) {
@@ -555,24 +689,40 @@ func (s *LitmusChaosServer) runChaosExperiment(ctx context.Context, args map[str
 		"experimentID": experimentID,
 	}
 
-	data, err := s.graphqlRequest(ctx, mutation, variables)
-	if err != nil {
-		return nil, err
+	var runResult RunChaosExperimentResponse
+	if err := s.query(ctx, mutation, variables, "runChaosExperiment", &runResult); err != nil {
+		return "", err
 	}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(data, &result); err != nil {
-		return nil, err
+	return runResult.NotifyID, nil
+}
+
+// runChaosExperiment triggers execution of a chaos experiment by its experimentId.
+func (s *LitmusChaosServer) runChaosExperiment(ctx context.Context, args map[string]interface{}) (*ToolResult, error) {
+	experimentID := getStringFromArgs(args, "experimentId", "")
+	if experimentID == "" {
+		return nil, fmt.Errorf("experimentId is required")
 	}
 
-	runResult := result["runChaosExperiment"].(map[string]interface{})
+	findings, preview, err := s.admitRun(ctx, experimentID, getStringFromArgs(args, "confirmationToken", ""))
+	if err != nil || preview != nil {
+		return preview, err
+	}
+
+	notifyID, err := s.startExperimentRun(ctx, experimentID)
+	if err != nil {
+		return nil, err
+	}
 
 	response := map[string]interface{}{
 		"success":      true,
 		"message":      "Chaos experiment started successfully",
-		"notifyId":     runResult["notifyID"],
+		"notifyId":     notifyID,
 		"experimentId": experimentID,
 	}
+	if len(findings) > 0 {
+		response["policyFindings"] = findings
+	}
 
 	responseJSON, _ := json.MarshalIndent(response, "", "  ")
 
@@ -617,17 +767,11 @@ func (s *LitmusChaosServer) stopChaosExperiment(ctx context.Context, args map[st
 		variables["experimentRunID"] = experimentRunID
 	}
 
-	data, err := s.graphqlRequest(ctx, mutation, variables)
-	if err != nil {
+	var success bool
+	if err := s.query(ctx, mutation, variables, "stopExperimentRuns", &success); err != nil {
 		return nil, err
 	}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(data, &result); err != nil {
-		return nil, err
-	}
-
-	success := result["stopExperimentRuns"].(bool)
 	message := "Failed to stop chaos experiment"
 	if success {
 		message = "Chaos experiment stopped successfully"
@@ -652,9 +796,8 @@ func (s *LitmusChaosServer) stopChaosExperiment(ctx context.Context, args map[st
 	}, nil
 }
 
-// listExperimentRuns lists experiment runs with optional filtering by experimentId and status.
-func (s *LitmusChaosServer) listExperimentRuns(ctx context.Context, args map[string]interface{}) (*ToolResult, error) {
-	query := `
+// listExperimentRunsQuery lists experiment runs with their scores, fault counters and infrastructure.
+const listExperimentRunsQuery = `
 		query ListExperimentRun($projectID: ID!, $request: ListExperimentRunRequest!) {
 			listExperimentRun(projectID: $projectID, request: $request) {
 				totalNoOfExperimentRuns
@@ -687,81 +830,192 @@ func (s *LitmusChaosServer) listExperimentRuns(ctx context.Context, args map[str
 		}
 	`
 
-	request := map[string]interface{}{
-		"pagination": map[string]interface{}{
-			"page":  0,
-			"limit": getIntFromArgs(args, "limit", 20),
-		},
+// Paging limits for list_experiment_runs
+const (
+	defaultRunsLimit     = 20
+	maxRunsLimit         = 100
+	defaultRunsMaxResult = 500
+	maxRunsMaxResult     = 5000
+)
+
+// listExperimentRuns lists experiment runs a page at a time, or every matching run up to maxResults with all,
+// filtered by experiment, status, infrastructure, creator and date range and sorted by time or resiliency score.
+// Chaos Center filters and sorts by everything except creator and score; with those, or with all, the matching
+// runs are read page by page and filtered, sorted and paged here.
+func (s *LitmusChaosServer) listExperimentRuns(ctx context.Context, args map[string]interface{}) (*ToolResult, error) {
+	page := getIntFromArgs(args, "page", 0)
+	if page < 0 {
+		return nil, fmt.Errorf("page must not be negative")
+	}
+	limit := getIntFromArgs(args, "limit", defaultRunsLimit)
+	if limit < 1 || limit > maxRunsLimit {
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxRunsLimit)
+	}
+	all := getBoolFromArgs(args, "all", false)
+	maxResults := getIntFromArgs(args, "maxResults", defaultRunsMaxResult)
+	if maxResults < 1 || maxResults > maxRunsMaxResult {
+		return nil, fmt.Errorf("maxResults must be between 1 and %d", maxRunsMaxResult)
 	}
 
-	if experimentID := getStringFromArgs(args, "experimentId", ""); experimentID != "" {
-		request["experimentIDs"] = []string{experimentID}
+	sortBy := getStringFromArgs(args, "sortBy", "time")
+	if sortBy != "time" && sortBy != "score" {
+		return nil, fmt.Errorf("sortBy must be time or score, got %q", sortBy)
 	}
+	order := getStringFromArgs(args, "order", "desc")
+	if order != "asc" && order != "desc" {
+		return nil, fmt.Errorf("order must be asc or desc, got %q", order)
+	}
+	creator := getStringFromArgs(args, "creator", "")
 
+	filter := map[string]interface{}{}
 	if status := getStringFromArgs(args, "status", ""); status != "" {
-		request["filter"] = map[string]interface{}{
-			"experimentStatus": status,
-		}
+		filter["experimentStatus"] = status
 	}
-
-	variables := map[string]interface{}{
-		"request": request,
+	if infraID := getStringFromArgs(args, "infraId", ""); infraID != "" {
+		filter["infraID"] = infraID
 	}
 
-	data, err := s.graphqlRequest(ctx, query, variables)
+	startDate, hasStart, err := getDateFromArgs(args, "startDate")
 	if err != nil {
 		return nil, err
 	}
-
-	var result map[string]interface{}
-	if err := json.Unmarshal(data, &result); err != nil {
+	endDate, hasEnd, err := getDateFromArgs(args, "endDate")
+	if err != nil {
 		return nil, err
 	}
+	if hasStart || hasEnd {
+		if !hasStart {
+			startDate = time.Unix(0, 0)
+		}
+		if !hasEnd {
+			endDate = time.Now()
+		}
+		if !startDate.Before(endDate) {
+			return nil, fmt.Errorf("startDate must be before endDate")
+		}
+		filter["dateRange"] = runDateRange(startDate, endDate)
+	}
 
-	listRuns := result["listExperimentRun"].(map[string]interface{})
-	runs := listRuns["experimentRuns"].([]interface{})
+	request := map[string]interface{}{
+		"sort": map[string]interface{}{
+			"field":     "TIME",
+			"ascending": sortBy == "time" && order == "asc",
+		},
+	}
+	if len(filter) > 0 {
+		request["filter"] = filter
+	}
+	if experimentID := getStringFromArgs(args, "experimentId", ""); experimentID != "" {
+		request["experimentIDs"] = []string{experimentID}
+	}
+
+	var runs []ExperimentRun
+	var total int
+	var notes []string
+	if !all && creator == "" && sortBy == "time" {
+		request["pagination"] = map[string]interface{}{
+			"page":  page,
+			"limit": limit,
+		}
+
+		variables := map[string]interface{}{
+			"request": request,
+		}
+
+		var listRuns ListExperimentRunResponse
+		if err := s.query(ctx, listExperimentRunsQuery, variables, "listExperimentRun", &listRuns); err != nil {
+			return nil, err
+		}
+		runs, total = listRuns.ExperimentRuns, listRuns.TotalNoOfExperimentRuns
+	} else {
+		fetched, matching, err := s.listAllExperimentRuns(ctx, request, maxResults)
+		if err != nil {
+			return nil, err
+		}
+		if len(fetched) < matching {
+			notes = append(notes, fmt.Sprintf("Only the first %d of %d matching runs were read; raise maxResults or narrow the filters", len(fetched), matching))
+		}
+
+		for _, run := range fetched {
+			if creator == "" || (run.CreatedBy != nil && strings.EqualFold(run.CreatedBy.Username, creator)) {
+				runs = append(runs, run)
+			}
+		}
+		if sortBy == "score" {
+			sort.SliceStable(runs, func(i, j int) bool {
+				a, b := runs[i].ResiliencyScore, runs[j].ResiliencyScore
+				if a == nil || b == nil {
+					// Runs without a score go last
+					return a != nil && b == nil
+				}
+				if order == "asc" {
+					return *a < *b
+				}
+				return *a > *b
+			})
+		}
+
+		total = len(runs)
+		if !all {
+			from, to := page*limit, page*limit+limit
+			if from > len(runs) {
+				from = len(runs)
+			}
+			if to > len(runs) {
+				to = len(runs)
+			}
+			runs = runs[from:to]
+		}
+	}
 
 	formattedRuns := make([]map[string]interface{}, len(runs))
 	for i, run := range runs {
-		runMap := run.(map[string]interface{})
-
 		infrastructure := map[string]interface{}{}
-		if infra := runMap["infra"]; infra != nil {
-			infraMap := infra.(map[string]interface{})
+		if infra := run.Infra; infra != nil {
 			infrastructure = map[string]interface{}{
-				"id":          infraMap["infraID"],
-				"name":        infraMap["name"],
-				"environment": infraMap["environmentID"],
-				"platform":    infraMap["platformName"],
+				"id":          infra.InfraID,
+				"name":        infra.Name,
+				"environment": infra.EnvironmentID,
+				"platform":    infra.PlatformName,
 			}
 		}
 
 		formattedRuns[i] = map[string]interface{}{
-			"id":             runMap["experimentRunID"],
-			"experimentId":   runMap["experimentID"],
-			"experimentName": runMap["experimentName"],
-			"status":         runMap["phase"],
-			"resiliencyScore": runMap["resiliencyScore"],
+			"id":              run.ExperimentRunID,
+			"experimentId":    run.ExperimentID,
+			"experimentName":  run.ExperimentName,
+			"status":          run.Phase,
+			"resiliencyScore": run.ResiliencyScore,
 			"faultsSummary": map[string]interface{}{
-				"passed":  runMap["faultsPassed"],
-				"failed":  runMap["faultsFailed"],
-				"awaited": runMap["faultsAwaited"],
-				"stopped": runMap["faultsStopped"],
-				"total":   runMap["totalFaults"],
+				"passed":  run.FaultsPassed,
+				"failed":  run.FaultsFailed,
+				"awaited": run.FaultsAwaited,
+				"stopped": run.FaultsStopped,
+				"total":   run.TotalFaults,
 			},
 			"infrastructure": infrastructure,
-			"sequence":       runMap["runSequence"],
-			"createdBy":      getNestedString(runMap, "createdBy", "username"),
-			"createdAt":      runMap["createdAt"],
-			"updatedAt":      runMap["updatedAt"],
+			"sequence":       run.RunSequence,
+			"createdBy":      run.CreatedBy.name(),
+			"createdAt":      run.CreatedAt,
+			"updatedAt":      run.UpdatedAt,
 		}
 	}
 
 	response := map[string]interface{}{
-		"summary":   fmt.Sprintf("Found %v experiment runs", listRuns["totalNoOfExperimentRuns"]),
-		"totalRuns": listRuns["totalNoOfExperimentRuns"],
+		"summary":   fmt.Sprintf("Found %d experiment runs", total),
+		"totalRuns": total,
 		"runs":      formattedRuns,
 	}
+	if all {
+		response["all"] = true
+	} else {
+		response["page"] = page
+		response["limit"] = limit
+		response["hasMore"] = (page+1)*limit < total
+	}
+	if len(notes) > 0 {
+		response["notes"] = notes
+	}
 
 	responseJSON, _ := json.MarshalIndent(response, "", "  ")
 
@@ -775,14 +1029,8 @@ func (s *LitmusChaosServer) listExperimentRuns(ctx context.Context, args map[str
 	}, nil
 }
 
-// getExperimentRunDetails fetches details for a specific experiment run identified by experimentRunId.
-func (s *LitmusChaosServer) getExperimentRunDetails(ctx context.Context, args map[string]interface{}) (*ToolResult, error) {
-	experimentRunID := getStringFromArgs(args, "experimentRunId", "")
-	if experimentRunID == "" {
-		return nil, fmt.Errorf("experimentRunId is required")
-	}
-
-	query := `
+// getExperimentRunQuery fetches a single experiment run, by run ID or by the notify ID returned when it was started.
+const getExperimentRunQuery = `
 		query GetExperimentRun(
 			$projectID: ID!,
 			$experimentRunID: ID,
@@ -829,66 +1077,84 @@ func (s *LitmusChaosServer) getExperimentRunDetails(ctx context.Context, args ma
 		}
 	`
 
-	variables := map[string]interface{}{
-		"experimentRunID": experimentRunID,
+// fetchExperimentRun runs getExperimentRunQuery and returns the getExperimentRun payload.
+// Either experimentRunID or notifyID must be set.
+func (s *LitmusChaosServer) fetchExperimentRun(ctx context.Context, experimentRunID, notifyID string) (*ExperimentRun, error) {
+	variables := map[string]interface{}{}
+	if experimentRunID != "" {
+		variables["experimentRunID"] = experimentRunID
+	}
+	if notifyID != "" {
+		variables["notifyID"] = notifyID
 	}
 
-	data, err := s.graphqlRequest(ctx, query, variables)
-	if err != nil {
-		return nil, err
+	var run ExperimentRun
+	if err := s.query(ctx, getExperimentRunQuery, variables, "getExperimentRun", &run); err != nil {
+		if experimentRunID == "" {
+			return nil, fmt.Errorf("no experiment run found for notifyID %s: %w", notifyID, err)
+		}
+		return nil, fmt.Errorf("failed to fetch experiment run %s: %w", experimentRunID, err)
 	}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(data, &result); err != nil {
-		return nil, err
+	return &run, nil
+}
+
+// getExperimentRunDetails fetches details for a specific experiment run identified by experimentRunId.
+func (s *LitmusChaosServer) getExperimentRunDetails(ctx context.Context, args map[string]interface{}) (*ToolResult, error) {
+	experimentRunID := getStringFromArgs(args, "experimentRunId", "")
+	if experimentRunID == "" {
+		return nil, fmt.Errorf("experimentRunId is required")
 	}
 
-	run := result["getExperimentRun"].(map[string]interface{})
+	run, err := s.fetchExperimentRun(ctx, experimentRunID, "")
+	if err != nil {
+		return nil, err
+	}
 
 	infrastructure := map[string]interface{}{}
-	if infra := run["infra"]; infra != nil {
-		infraMap := infra.(map[string]interface{})
+	if infra := run.Infra; infra != nil {
 		infrastructure = map[string]interface{}{
-			"id":          infraMap["infraID"],
-			"name":        infraMap["name"],
-			"environment": infraMap["environmentID"],
-			"platform":    infraMap["platformName"],
-			"version":     infraMap["version"],
+			"id":          infra.InfraID,
+			"name":        infra.Name,
+			"environment": infra.EnvironmentID,
+			"platform":    infra.PlatformName,
+			"version":     infra.Version,
 		}
 	}
 
-	var executionData interface{}
-	if getBoolFromArgs(args, "includeLogs", false) {
-		if execData := run["executionData"]; execData != nil {
-			if execStr, ok := execData.(string); ok && execStr != "" {
-				json.Unmarshal([]byte(execStr), &executionData)
-			}
+	var execution *executionReport
+	var executionErr error
+	if getBoolFromArgs(args, "includeLogs", false) && run.ExecutionData != "" {
+		var parsed *executionData
+		if parsed, executionErr = parseExecutionData(run.ExecutionData); executionErr == nil {
+			execution = parsed.report()
 		}
 	}
 
+	details := map[string]interface{}{
+		"id":              run.ExperimentRunID,
+		"experimentId":    run.ExperimentID,
+		"experimentName":  run.ExperimentName,
+		"status":          run.Phase,
+		"resiliencyScore": run.ResiliencyScore,
+		"faultsSummary":   runFaultsSummary(run),
+		"infrastructure":  infrastructure,
+		"execution":       execution,
+		"sequence":        run.RunSequence,
+		"createdBy":       run.CreatedBy.name(),
+		"updatedBy":       run.UpdatedBy.name(),
+		"createdAt":       run.CreatedAt,
+		"updatedAt":       run.UpdatedAt,
+	}
+
+	// Execution data that cannot be parsed is returned as is, so the caller can still read it
+	if executionErr != nil {
+		details["executionError"] = executionErr.Error()
+		details["executionData"] = run.ExecutionData
+	}
+
 	response := map[string]interface{}{
-		"run": map[string]interface{}{
-			"id":             run["experimentRunID"],
-			"experimentId":   run["experimentID"],
-			"experimentName": run["experimentName"],
-			"status":         run["phase"],
-			"resiliencyScore": run["resiliencyScore"],
-			"faultsSummary": map[string]interface{}{
-				"passed":        run["faultsPassed"],
-				"failed":        run["faultsFailed"],
-				"awaited":       run["faultsAwaited"],
-				"stopped":       run["faultsStopped"],
-				"notApplicable": run["faultsNa"],
-				"total":         run["totalFaults"],
-			},
-			"infrastructure": infrastructure,
-			"executionData":  executionData,
-			"sequence":       run["runSequence"],
-			"createdBy":      getNestedString(run, "createdBy", "username"),
-			"updatedBy":      getNestedString(run, "updatedBy", "username"),
-			"createdAt":      run["createdAt"],
-			"updatedAt":      run["updatedAt"],
-		},
+		"run": details,
 	}
 
 	responseJSON, _ := json.MarshalIndent(response, "", "  ")
@@ -952,49 +1218,39 @@ func (s *LitmusChaosServer) listChaosInfrastructures(ctx context.Context, args m
 		variables["request"] = request
 	}
 
-	data, err := s.graphqlRequest(ctx, query, variables)
-	if err != nil {
+	var listInfras ListInfraResponse
+	if err := s.query(ctx, query, variables, "listInfras", &listInfras); err != nil {
 		return nil, err
 	}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(data, &result); err != nil {
-		return nil, err
-	}
-
-	listInfras := result["listInfras"].(map[string]interface{})
-	infras := listInfras["infras"].([]interface{})
-
-	formattedInfras := make([]map[string]interface{}, len(infras))
-	for i, infra := range infras {
-		infraMap := infra.(map[string]interface{})
-
+	formattedInfras := make([]map[string]interface{}, len(listInfras.Infras))
+	for i, infra := range listInfras.Infras {
 		formattedInfras[i] = map[string]interface{}{
-			"id":          infraMap["infraID"],
-			"name":        infraMap["name"],
-			"description": infraMap["description"],
-			"environment": infraMap["environmentID"],
-			"platform":    infraMap["platformName"],
-			"active":      infraMap["isActive"],
-			"confirmed":   infraMap["isInfraConfirmed"],
-			"scope":       infraMap["infraScope"],
-			"namespace":   infraMap["infraNamespace"],
-			"version":     infraMap["version"],
+			"id":          infra.InfraID,
+			"name":        infra.Name,
+			"description": infra.Description,
+			"environment": infra.EnvironmentID,
+			"platform":    infra.PlatformName,
+			"active":      infra.IsActive,
+			"confirmed":   infra.IsInfraConfirmed,
+			"scope":       infra.InfraScope,
+			"namespace":   infra.InfraNamespace,
+			"version":     infra.Version,
 			"statistics": map[string]interface{}{
-				"experiments": infraMap["noOfExperiments"],
-				"runs":        infraMap["noOfExperimentRuns"],
+				"experiments": infra.NoOfExperiments,
+				"runs":        infra.NoOfExperimentRuns,
 			},
-			"tags":         infraMap["tags"],
-			"updateStatus": infraMap["updateStatus"],
-			"createdBy":    getNestedString(infraMap, "createdBy", "username"),
-			"createdAt":    infraMap["createdAt"],
-			"updatedAt":    infraMap["updatedAt"],
+			"tags":         infra.Tags,
+			"updateStatus": infra.UpdateStatus,
+			"createdBy":    infra.CreatedBy.name(),
+			"createdAt":    infra.CreatedAt,
+			"updatedAt":    infra.UpdatedAt,
 		}
 	}
 
 	response := map[string]interface{}{
-		"summary":              fmt.Sprintf("Found %v chaos infrastructures", listInfras["totalNoOfInfras"]),
-		"totalInfrastructures": listInfras["totalNoOfInfras"],
+		"summary":              fmt.Sprintf("Found %d chaos infrastructures", listInfras.TotalNoOfInfras),
+		"totalInfrastructures": listInfras.TotalNoOfInfras,
 		"infrastructures":      formattedInfras,
 	}
 
@@ -1010,14 +1266,8 @@ func (s *LitmusChaosServer) listChaosInfrastructures(ctx context.Context, args m
 	}, nil
 }
 
-// getInfrastructureDetails returns detailed information for the given infraId, and optionally includes the install manifest.
-func (s *LitmusChaosServer) getInfrastructureDetails(ctx context.Context, args map[string]interface{}) (*ToolResult, error) {
-	infraID := getStringFromArgs(args, "infraId", "")
-	if infraID == "" {
-		return nil, fmt.Errorf("infraId is required")
-	}
-
-	query := `
+// getInfraQuery fetches a single chaos infrastructure.
+const getInfraQuery = `
 		query GetInfra($projectID: ID!, $infraID: String!) {
 			getInfra(projectID: $projectID, infraID: $infraID) {
 				projectID
@@ -1055,25 +1305,8 @@ func (s *LitmusChaosServer) getInfrastructureDetails(ctx context.Context, args m
 		}
 	`
 
-	variables := map[string]interface{}{
-		"infraID": infraID,
-	}
-
-	data, err := s.graphqlRequest(ctx, query, variables)
-	if err != nil {
-		return nil, err
-	}
-
-	var result map[string]interface{}
-	if err := json.Unmarshal(data, &result); err != nil {
-		return nil, err
-	}
-
-	infra := result["getInfra"].(map[string]interface{})
-
-	var manifest interface{} = nil
-	if getBoolFromArgs(args, "includeManifest", false) {
-		manifestQuery := `
+// getInfraManifestQuery fetches the installation manifest of a chaos infrastructure.
+const getInfraManifestQuery = `
 			query GetInfraManifest(
 				$infraID: ID!,
 				$upgrade: Boolean!,
@@ -1087,17 +1320,54 @@ func (s *LitmusChaosServer) getInfrastructureDetails(ctx context.Context, args m
 			}
 		`
 
-		manifestVars := map[string]interface{}{
-			"infraID": infraID,
-			"upgrade": false,
-		}
+// fetchInfra runs getInfraQuery and returns the getInfra payload.
+func (s *LitmusChaosServer) fetchInfra(ctx context.Context, infraID string) (*Infra, error) {
+	variables := map[string]interface{}{
+		"infraID": infraID,
+	}
 
-		manifestData, manifestErr := s.graphqlRequest(ctx, manifestQuery, manifestVars)
-		if manifestErr == nil {
-			var manifestResult map[string]interface{}
-			if json.Unmarshal(manifestData, &manifestResult) == nil {
-				manifest = manifestResult["getInfraManifest"]
-			}
+	var infra Infra
+	if err := s.query(ctx, getInfraQuery, variables, "getInfra", &infra); err != nil {
+		return nil, err
+	}
+
+	return &infra, nil
+}
+
+// fetchInfraManifest runs getInfraManifestQuery and returns the installation manifest YAML.
+func (s *LitmusChaosServer) fetchInfraManifest(ctx context.Context, infraID string) (string, error) {
+	variables := map[string]interface{}{
+		"infraID": infraID,
+		"upgrade": false,
+	}
+
+	var manifest string
+	if err := s.query(ctx, getInfraManifestQuery, variables, "getInfraManifest", &manifest); err != nil {
+		return "", err
+	}
+	if manifest == "" {
+		return "", fmt.Errorf("manifest not available for infrastructure %s", infraID)
+	}
+
+	return manifest, nil
+}
+
+// getInfrastructureDetails returns detailed information for the given infraId, and optionally includes the install manifest.
+func (s *LitmusChaosServer) getInfrastructureDetails(ctx context.Context, args map[string]interface{}) (*ToolResult, error) {
+	infraID := getStringFromArgs(args, "infraId", "")
+	if infraID == "" {
+		return nil, fmt.Errorf("infraId is required")
+	}
+
+	infra, err := s.fetchInfra(ctx, infraID)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest interface{} = nil
+	if getBoolFromArgs(args, "includeManifest", false) {
+		if infraManifest, manifestErr := s.fetchInfraManifest(ctx, infraID); manifestErr == nil {
+			manifest = infraManifest
 		}
 		if manifest == nil {
 			manifest = "Manifest not available"
@@ -1106,32 +1376,32 @@ func (s *LitmusChaosServer) getInfrastructureDetails(ctx context.Context, args m
 
 	response := map[string]interface{}{
 		"infrastructure": map[string]interface{}{
-			"id":          infra["infraID"],
-			"name":        infra["name"],
-			"description": infra["description"],
-			"environment": infra["environmentID"],
-			"platform":    infra["platformName"],
-			"active":      infra["isActive"],
-			"confirmed":   infra["isInfraConfirmed"],
-			"scope":       infra["infraScope"],
-			"namespace":   infra["infraNamespace"],
-			"serviceAccount": infra["serviceAccount"],
-			"namespaceExists": infra["infraNsExists"],
-			"serviceAccountExists": infra["infraSaExists"],
-			"version":     infra["version"],
+			"id":                   infra.InfraID,
+			"name":                 infra.Name,
+			"description":          infra.Description,
+			"environment":          infra.EnvironmentID,
+			"platform":             infra.PlatformName,
+			"active":               infra.IsActive,
+			"confirmed":            infra.IsInfraConfirmed,
+			"scope":                infra.InfraScope,
+			"namespace":            infra.InfraNamespace,
+			"serviceAccount":       infra.ServiceAccount,
+			"namespaceExists":      infra.InfraNsExists,
+			"serviceAccountExists": infra.InfraSaExists,
+			"version":              infra.Version,
 			"statistics": map[string]interface{}{
-				"experiments":     infra["noOfExperiments"],
-				"runs":           infra["noOfExperimentRuns"],
-				"lastExperiment": infra["lastExperimentTimestamp"],
+				"experiments":    infra.NoOfExperiments,
+				"runs":           infra.NoOfExperimentRuns,
+				"lastExperiment": infra.LastExperimentTimestamp,
 			},
-			"startTime":     infra["startTime"],
-			"tags":          infra["tags"],
-			"updateStatus":  infra["updateStatus"],
-			"createdBy":     getNestedString(infra, "createdBy", "username"),
-			"updatedBy":     getNestedString(infra, "updatedBy", "username"),
-			"createdAt":     infra["createdAt"],
-			"updatedAt":     infra["updatedAt"],
-			"manifest":      manifest,
+			"startTime":    infra.StartTime,
+			"tags":         infra.Tags,
+			"updateStatus": infra.UpdateStatus,
+			"createdBy":    infra.CreatedBy.name(),
+			"updatedBy":    infra.UpdatedBy.name(),
+			"createdAt":    infra.CreatedAt,
+			"updatedAt":    infra.UpdatedAt,
+			"manifest":     manifest,
 		},
 	}
 
@@ -1147,9 +1417,8 @@ func (s *LitmusChaosServer) getInfrastructureDetails(ctx context.Context, args m
 	}, nil
 }
 
-// listEnvironments lists environments with optional filtering by type.
-func (s *LitmusChaosServer) listEnvironments(ctx context.Context, args map[string]interface{}) (*ToolResult, error) {
-	query := `
+// listEnvironmentsQuery lists environments with their infrastructure IDs.
+const listEnvironmentsQuery = `
 		query ListEnvironments($projectID: ID!, $request: ListEnvironmentRequest) {
 			listEnvironments(projectID: $projectID, request: $request) {
 				totalNoOfEnvironments
@@ -1174,6 +1443,8 @@ func (s *LitmusChaosServer) listEnvironments(ctx context.Context, args map[strin
 		}
 	`
 
+// listEnvironments lists environments with optional filtering by type.
+func (s *LitmusChaosServer) listEnvironments(ctx context.Context, args map[string]interface{}) (*ToolResult, error) {
 	request := map[string]interface{}{}
 
 	if envType := getStringFromArgs(args, "type", ""); envType != "" {
@@ -1187,48 +1458,31 @@ func (s *LitmusChaosServer) listEnvironments(ctx context.Context, args map[strin
 		variables["request"] = request
 	}
 
-	data, err := s.graphqlRequest(ctx, query, variables)
-	if err != nil {
-		return nil, err
-	}
-
-	var result map[string]interface{}
-	if err := json.Unmarshal(data, &result); err != nil {
+	var listEnvs ListEnvironmentResponse
+	if err := s.query(ctx, listEnvironmentsQuery, variables, "listEnvironments", &listEnvs); err != nil {
 		return nil, err
 	}
 
-	listEnvs := result["listEnvironments"].(map[string]interface{})
-	environments := listEnvs["environments"].([]interface{})
-
-	formattedEnvs := make([]map[string]interface{}, len(environments))
-	for i, env := range environments {
-		envMap := env.(map[string]interface{})
-
-		infraCount := 0
-		var infraIDs []interface{}
-		if infraIDsRaw := envMap["infraIDs"]; infraIDsRaw != nil {
-			infraIDs = infraIDsRaw.([]interface{})
-			infraCount = len(infraIDs)
-		}
-
+	formattedEnvs := make([]map[string]interface{}, len(listEnvs.Environments))
+	for i, env := range listEnvs.Environments {
 		formattedEnvs[i] = map[string]interface{}{
-			"id":                    envMap["environmentID"],
-			"name":                  envMap["name"],
-			"description":           envMap["description"],
-			"type":                  envMap["type"],
-			"tags":                  envMap["tags"],
-			"infrastructureCount":   infraCount,
-			"infrastructureIds":     infraIDs,
-			"createdBy":             getNestedString(envMap, "createdBy", "username"),
-			"updatedBy":             getNestedString(envMap, "updatedBy", "username"),
-			"createdAt":             envMap["createdAt"],
-			"updatedAt":             envMap["updatedAt"],
+			"id":                  env.EnvironmentID,
+			"name":                env.Name,
+			"description":         env.Description,
+			"type":                env.Type,
+			"tags":                env.Tags,
+			"infrastructureCount": len(env.InfraIDs),
+			"infrastructureIds":   env.InfraIDs,
+			"createdBy":           env.CreatedBy.name(),
+			"updatedBy":           env.UpdatedBy.name(),
+			"createdAt":           env.CreatedAt,
+			"updatedAt":           env.UpdatedAt,
 		}
 	}
 
 	response := map[string]interface{}{
-		"summary":           fmt.Sprintf("Found %v environments", listEnvs["totalNoOfEnvironments"]),
-		"totalEnvironments": listEnvs["totalNoOfEnvironments"],
+		"summary":           fmt.Sprintf("Found %d environments", listEnvs.TotalNoOfEnvironments),
+		"totalEnvironments": listEnvs.TotalNoOfEnvironments,
 		"environments":      formattedEnvs,
 	}
 
@@ -1293,29 +1547,22 @@ func (s *LitmusChaosServer) createEnvironment(ctx context.Context, args map[stri
 		"request": request,
 	}
 
-	data, err := s.graphqlRequest(ctx, mutation, variables)
-	if err != nil {
-		return nil, err
-	}
-
-	var result map[string]interface{}
-	if err := json.Unmarshal(data, &result); err != nil {
+	var createResult Environment
+	if err := s.query(ctx, mutation, variables, "createEnvironment", &createResult); err != nil {
 		return nil, err
 	}
 
-	createResult := result["createEnvironment"].(map[string]interface{})
-
 	response := map[string]interface{}{
 		"success": true,
 		"message": fmt.Sprintf("Environment '%s' created successfully", name),
 		"environment": map[string]interface{}{
-			"id":          createResult["environmentID"],
-			"name":        createResult["name"],
-			"description": createResult["description"],
-			"type":        createResult["type"],
-			"tags":        createResult["tags"],
-			"createdBy":   getNestedString(createResult, "createdBy", "username"),
-			"createdAt":   createResult["createdAt"],
+			"id":          createResult.EnvironmentID,
+			"name":        createResult.Name,
+			"description": createResult.Description,
+			"type":        createResult.Type,
+			"tags":        createResult.Tags,
+			"createdBy":   createResult.CreatedBy.name(),
+			"createdAt":   createResult.CreatedAt,
 		},
 	}
 
@@ -1331,9 +1578,8 @@ func (s *LitmusChaosServer) createEnvironment(ctx context.Context, args map[stri
 	}, nil
 }
 
-// listResilienceProbes lists available resilience probes with optional filtering by probe type.
-func (s *LitmusChaosServer) listResilienceProbes(ctx context.Context, args map[string]interface{}) (*ToolResult, error) {
-	query := `
+// listProbesQuery lists resilience probes, optionally filtered by type.
+const listProbesQuery = `
 		query ListProbes(
 			$projectID: ID!,
 			$infrastructureType: InfrastructureType,
@@ -1365,6 +1611,8 @@ func (s *LitmusChaosServer) listResilienceProbes(ctx context.Context, args map[s
 		}
 	`
 
+// listResilienceProbes lists available resilience probes with optional filtering by probe type.
+func (s *LitmusChaosServer) listResilienceProbes(ctx context.Context, args map[string]interface{}) (*ToolResult, error) {
 	variables := map[string]interface{}{}
 
 	if probeType := getStringFromArgs(args, "type", ""); probeType != "" {
@@ -1373,33 +1621,24 @@ func (s *LitmusChaosServer) listResilienceProbes(ctx context.Context, args map[s
 		}
 	}
 
-	data, err := s.graphqlRequest(ctx, query, variables)
-	if err != nil {
-		return nil, err
-	}
-
-	var result map[string]interface{}
-	if err := json.Unmarshal(data, &result); err != nil {
+	var probes ProbeList
+	if err := s.query(ctx, listProbesQuery, variables, "listProbes", &probes); err != nil {
 		return nil, err
 	}
 
-	probes := result["listProbes"].([]interface{})
-
 	formattedProbes := make([]map[string]interface{}, len(probes))
 	for i, probe := range probes {
-		probeMap := probe.(map[string]interface{})
-
 		formattedProbes[i] = map[string]interface{}{
-			"name":               probeMap["name"],
-			"description":        probeMap["description"],
-			"type":               probeMap["type"],
-			"infrastructureType": probeMap["infrastructureType"],
-			"tags":               probeMap["tags"],
-			"referencedBy":       probeMap["referencedBy"],
-			"createdBy":          getNestedString(probeMap, "createdBy", "username"),
-			"updatedBy":          getNestedString(probeMap, "updatedBy", "username"),
-			"createdAt":          probeMap["createdAt"],
-			"updatedAt":          probeMap["updatedAt"],
+			"name":               probe.Name,
+			"description":        probe.Description,
+			"type":               probe.Type,
+			"infrastructureType": probe.InfrastructureType,
+			"tags":               probe.Tags,
+			"referencedBy":       probe.ReferencedBy,
+			"createdBy":          probe.CreatedBy.name(),
+			"updatedBy":          probe.UpdatedBy.name(),
+			"createdAt":          probe.CreatedAt,
+			"updatedAt":          probe.UpdatedAt,
 		}
 	}
 
@@ -1421,51 +1660,11 @@ func (s *LitmusChaosServer) listResilienceProbes(ctx context.Context, args map[s
 	}, nil
 }
 
-// createResilienceProbe creates a new resilience probe of the specified type using provided properties and optional tags.
-func (s *LitmusChaosServer) createResilienceProbe(ctx context.Context, args map[string]interface{}) (*ToolResult, error) {
-	name := getStringFromArgs(args, "name", "")
-	if name == "" {
-		return nil, fmt.Errorf("name is required")
-	}
-
-	probeType := getStringFromArgs(args, "type", "")
-	if probeType == "" {
-		return nil, fmt.Errorf("type is required")
-	}
-
-	properties := getMapFromArgs(args, "properties")
-	if properties == nil {
-		return nil, fmt.Errorf("properties are required")
-	}
-
-	mutation := `
-		mutation AddProbe($request: ProbeRequest!, $projectID: ID!) {
-			addProbe(request: $request, projectID: $projectID) {
-				projectID
-				name
-				description
-				type
-				infrastructureType
-				tags
-				createdAt
-				createdBy {
-					username
-				}
-			}
-		}
-	`
-
-	tags := []string{}
-	if tagsSlice := getSliceFromArgs(args, "tags"); tagsSlice != nil {
-		tags = make([]string, len(tagsSlice))
-		for i, tag := range tagsSlice {
-			tags[i] = fmt.Sprintf("%v", tag)
-		}
-	}
-
+// probeRequest builds the ProbeRequest for a probe of the given type from the create_resilience_probe properties.
+func probeRequest(name, probeType, description string, tags []string, properties map[string]interface{}) map[string]interface{} {
 	request := map[string]interface{}{
 		"name":               name,
-		"description":        getStringFromArgs(args, "description", ""),
+		"description":        description,
 		"type":               probeType,
 		"infrastructureType": "Kubernetes",
 		"tags":               tags,
@@ -1534,33 +1733,73 @@ func (s *LitmusChaosServer) createResilienceProbe(ctx context.Context, args map[
 		}
 	}
 
-	variables := map[string]interface{}{
-		"request": request,
+	return request
+}
+
+// createResilienceProbe creates a new resilience probe of the specified type using provided properties and optional tags.
+func (s *LitmusChaosServer) createResilienceProbe(ctx context.Context, args map[string]interface{}) (*ToolResult, error) {
+	name := getStringFromArgs(args, "name", "")
+	if name == "" {
+		return nil, fmt.Errorf("name is required")
 	}
 
-	data, err := s.graphqlRequest(ctx, mutation, variables)
-	if err != nil {
-		return nil, err
+	probeType := getStringFromArgs(args, "type", "")
+	if probeType == "" {
+		return nil, fmt.Errorf("type is required")
 	}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(data, &result); err != nil {
-		return nil, err
+	properties := getMapFromArgs(args, "properties")
+	if properties == nil {
+		return nil, fmt.Errorf("properties are required")
 	}
 
-	createResult := result["addProbe"].(map[string]interface{})
+	mutation := `
+		mutation AddProbe($request: ProbeRequest!, $projectID: ID!) {
+			addProbe(request: $request, projectID: $projectID) {
+				projectID
+				name
+				description
+				type
+				infrastructureType
+				tags
+				createdAt
+				createdBy {
+					username
+				}
+			}
+		}
+	`
+
+	tags := []string{}
+	if tagsSlice := getSliceFromArgs(args, "tags"); tagsSlice != nil {
+		tags = make([]string, len(tagsSlice))
+		for i, tag := range tagsSlice {
+			tags[i] = fmt.Sprintf("%v", tag)
+		}
+	}
+
+	request := probeRequest(name, probeType, getStringFromArgs(args, "description", ""), tags, properties)
+
+	variables := map[string]interface{}{
+		"request": request,
+	}
+
+	var createResult Probe
+	if err := s.query(ctx, mutation, variables, "addProbe", &createResult); err != nil {
+		return nil, err
+	}
 
 	response := map[string]interface{}{
 		"success": true,
 		"message": fmt.Sprintf("Resilience probe '%s' created successfully", name),
 		"probe": map[string]interface{}{
-			"name":               createResult["name"],
-			"description":        createResult["description"],
-			"type":               createResult["type"],
-			"infrastructureType": createResult["infrastructureType"],
-			"tags":               createResult["tags"],
-			"createdBy":          getNestedString(createResult, "createdBy", "username"),
-			"createdAt":          createResult["createdAt"],
+			"name":               createResult.Name,
+			"description":        createResult.Description,
+			"type":               createResult.Type,
+			"infrastructureType": createResult.InfrastructureType,
+			"tags":               createResult.Tags,
+			"createdBy":          createResult.CreatedBy.name(),
+			"createdAt":          createResult.CreatedAt,
 		},
 	}
 
@@ -1619,42 +1858,33 @@ func (s *LitmusChaosServer) listChaosHubs(ctx context.Context, args map[string]i
 		variables["request"] = request
 	}
 
-	data, err := s.graphqlRequest(ctx, query, variables)
-	if err != nil {
-		return nil, err
-	}
-
-	var result map[string]interface{}
-	if err := json.Unmarshal(data, &result); err != nil {
+	var hubs ChaosHubList
+	if err := s.query(ctx, query, variables, "listChaosHub", &hubs); err != nil {
 		return nil, err
 	}
 
-	hubs := result["listChaosHub"].([]interface{})
-
 	formattedHubs := make([]map[string]interface{}, len(hubs))
 	for i, hub := range hubs {
-		hubMap := hub.(map[string]interface{})
-
 		formattedHubs[i] = map[string]interface{}{
-			"id":          hubMap["id"],
-			"name":        hubMap["name"],
-			"description": hubMap["description"],
-			"repoUrl":     hubMap["repoURL"],
-			"branch":      hubMap["repoBranch"],
-			"remoteHub":   hubMap["remoteHub"],
-			"type":        hubMap["hubType"],
-			"private":     hubMap["isPrivate"],
-			"available":   hubMap["isAvailable"],
+			"id":          hub.ID,
+			"name":        hub.Name,
+			"description": hub.Description,
+			"repoUrl":     hub.RepoURL,
+			"branch":      hub.RepoBranch,
+			"remoteHub":   hub.RemoteHub,
+			"type":        hub.HubType,
+			"private":     hub.IsPrivate,
+			"available":   hub.IsAvailable,
 			"statistics": map[string]interface{}{
-				"totalFaults":      hubMap["totalFaults"],
-				"totalExperiments": hubMap["totalExperiments"],
+				"totalFaults":      hub.TotalFaults,
+				"totalExperiments": hub.TotalExperiments,
 			},
-			"tags":        hubMap["tags"],
-			"lastSynced":  hubMap["lastSyncedAt"],
-			"createdBy":   getNestedString(hubMap, "createdBy", "username"),
-			"updatedBy":   getNestedString(hubMap, "updatedBy", "username"),
-			"createdAt":   hubMap["createdAt"],
-			"updatedAt":   hubMap["updatedAt"],
+			"tags":       hub.Tags,
+			"lastSynced": hub.LastSyncedAt,
+			"createdBy":  hub.CreatedBy.name(),
+			"updatedBy":  hub.UpdatedBy.name(),
+			"createdAt":  hub.CreatedAt,
+			"updatedAt":  hub.UpdatedAt,
 		}
 	}
 
@@ -1718,62 +1948,41 @@ func (s *LitmusChaosServer) getChaosFaults(ctx context.Context, args map[string]
 		"hubID": hubID,
 	}
 
-	data, err := s.graphqlRequest(ctx, query, variables)
-	if err != nil {
+	var faultCategories FaultCategoryList
+	if err := s.query(ctx, query, variables, "listChaosFaults", &faultCategories); err != nil {
 		return nil, err
 	}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(data, &result); err != nil {
-		return nil, err
-	}
-
-	faultCategories := result["listChaosFaults"].([]interface{})
-
 	category := getStringFromArgs(args, "category", "")
 	filteredCategories := make([]map[string]interface{}, 0)
 
 	for _, cat := range faultCategories {
-		categoryMap := cat.(map[string]interface{})
-		metadata := categoryMap["metadata"].(map[string]interface{})
-		spec := categoryMap["spec"].(map[string]interface{})
-
-		categoryName := metadata["name"].(string)
+		categoryName := cat.Metadata.Name
 		if category == "" || strings.Contains(strings.ToLower(categoryName), strings.ToLower(category)) {
-			var annotations map[string]interface{}
-			if ann := metadata["annotations"]; ann != nil {
-				annotations = ann.(map[string]interface{})
-			}
-
-			var faults []map[string]interface{}
-			if faultsList := spec["faults"]; faultsList != nil {
-				faultsSlice := faultsList.([]interface{})
-				faults = make([]map[string]interface{}, len(faultsSlice))
-				for i, fault := range faultsSlice {
-					faultMap := fault.(map[string]interface{})
-					faults[i] = map[string]interface{}{
-						"name":        faultMap["name"],
-						"displayName": faultMap["displayName"],
-						"description": faultMap["description"],
-					}
+			faults := make([]map[string]interface{}, len(cat.Spec.Faults))
+			for i, fault := range cat.Spec.Faults {
+				faults[i] = map[string]interface{}{
+					"name":        fault.Name,
+					"displayName": fault.DisplayName,
+					"description": fault.Description,
 				}
 			}
 
 			formattedCategory := map[string]interface{}{
 				"name":        categoryName,
-				"displayName": spec["displayName"],
-				"description": spec["categoryDescription"],
-				"version":     metadata["version"],
-				"keywords":    spec["keywords"],
-				"maturity":    spec["maturity"],
-				"platforms":   spec["platforms"],
-				"chaosType":   spec["chaosType"],
+				"displayName": cat.Spec.DisplayName,
+				"description": cat.Spec.CategoryDescription,
+				"version":     cat.Metadata.Version,
+				"keywords":    cat.Spec.Keywords,
+				"maturity":    cat.Spec.Maturity,
+				"platforms":   cat.Spec.Platforms,
+				"chaosType":   cat.Spec.ChaosType,
 				"faults":      faults,
 			}
 
-			if annotations != nil {
-				formattedCategory["vendor"] = annotations["vendor"]
-				formattedCategory["repository"] = annotations["repository"]
+			if annotations := cat.Metadata.Annotations; annotations != nil {
+				formattedCategory["vendor"] = annotations.Vendor
+				formattedCategory["repository"] = annotations.Repository
 			}
 
 			filteredCategories = append(filteredCategories, formattedCategory)
@@ -1883,46 +2092,44 @@ func (s *LitmusChaosServer) getExperimentStatistics(ctx context.Context, args ma
 	}
 
 	// Parse results
-	var expStats, runStats, infraStats map[string]interface{}
+	var expStats ExperimentStats
+	var runStats ExperimentRunStats
+	var infraStats InfraStats
 
-	if err := json.Unmarshal(expStatsData, &expStats); err != nil {
+	if err := decodeField(expStatsData, "getExperimentStats", &expStats); err != nil {
 		return nil, err
 	}
-	if err := json.Unmarshal(runStatsData, &runStats); err != nil {
+	if err := decodeField(runStatsData, "getExperimentRunStats", &runStats); err != nil {
 		return nil, err
 	}
-	if err := json.Unmarshal(infraStatsData, &infraStats); err != nil {
+	if err := decodeField(infraStatsData, "getInfraStats", &infraStats); err != nil {
 		return nil, err
 	}
 
-	expStatsResult := expStats["getExperimentStats"].(map[string]interface{})
-	runStatsResult := runStats["getExperimentRunStats"].(map[string]interface{})
-	infraStatsResult := infraStats["getInfraStats"].(map[string]interface{})
-
 	response := map[string]interface{}{
 		"overview": map[string]interface{}{
-			"totalExperiments":      expStatsResult["totalExperiments"],
-			"totalExperimentRuns":   runStatsResult["totalExperimentRuns"],
-			"totalInfrastructures":  infraStatsResult["totalInfrastructures"],
+			"totalExperiments":     expStats.TotalExperiments,
+			"totalExperimentRuns":  runStats.TotalExperimentRuns,
+			"totalInfrastructures": infraStats.TotalInfrastructures,
 		},
 		"experimentStatistics": map[string]interface{}{
-			"total": expStatsResult["totalExperiments"],
-			"resiliencyScoreDistribution": expStatsResult["totalExpCategorizedByResiliencyScore"],
+			"total":                       expStats.TotalExperiments,
+			"resiliencyScoreDistribution": expStats.TotalExpCategorizedByResiliencyScore,
 		},
 		"experimentRunStatistics": map[string]interface{}{
-			"total":      runStatsResult["totalExperimentRuns"],
-			"completed":  runStatsResult["totalCompletedExperimentRuns"],
-			"terminated": runStatsResult["totalTerminatedExperimentRuns"],
-			"running":    runStatsResult["totalRunningExperimentRuns"],
-			"stopped":    runStatsResult["totalStoppedExperimentRuns"],
-			"errored":    runStatsResult["totalErroredExperimentRuns"],
+			"total":      runStats.TotalExperimentRuns,
+			"completed":  runStats.TotalCompletedExperimentRuns,
+			"terminated": runStats.TotalTerminatedExperimentRuns,
+			"running":    runStats.TotalRunningExperimentRuns,
+			"stopped":    runStats.TotalStoppedExperimentRuns,
+			"errored":    runStats.TotalErroredExperimentRuns,
 		},
 		"infrastructureStatistics": map[string]interface{}{
-			"total":       infraStatsResult["totalInfrastructures"],
-			"active":      infraStatsResult["totalActiveInfrastructure"],
-			"inactive":    infraStatsResult["totalInactiveInfrastructures"],
-			"confirmed":   infraStatsResult["totalConfirmedInfrastructure"],
-			"unconfirmed": infraStatsResult["totalNonConfirmedInfrastructures"],
+			"total":       infraStats.TotalInfrastructures,
+			"active":      infraStats.TotalActiveInfrastructure,
+			"inactive":    infraStats.TotalInactiveInfrastructures,
+			"confirmed":   infraStats.TotalConfirmedInfrastructure,
+			"unconfirmed": infraStats.TotalNonConfirmedInfrastructures,
 		},
 	}
 
@@ -1993,28 +2200,21 @@ func (s *LitmusChaosServer) registerChaosInfrastructure(ctx context.Context, arg
 		"request": request,
 	}
 
-	data, err := s.graphqlRequest(ctx, mutation, variables)
-	if err != nil {
+	var registerResult RegisterInfraResponse
+	if err := s.query(ctx, mutation, variables, "registerInfra", &registerResult); err != nil {
 		return nil, err
 	}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(data, &result); err != nil {
-		return nil, err
-	}
-
-	registerResult := result["registerInfra"].(map[string]interface{})
-
 	response := map[string]interface{}{
 		"success": true,
 		"message": fmt.Sprintf("Chaos infrastructure '%s' registered successfully", name),
 		"infrastructure": map[string]interface{}{
-			"id":    registerResult["infraID"],
-			"name":  registerResult["name"],
-			"token": registerResult["token"],
+			"id":    registerResult.InfraID,
+			"name":  registerResult.Name,
+			"token": registerResult.Token,
 			"installationInstructions": map[string]interface{}{
 				"step1":    "Apply the following manifest to your Kubernetes cluster:",
-				"manifest": registerResult["manifest"],
+				"manifest": registerResult.Manifest,
 				"step2":    "Wait for the infrastructure to be confirmed in the Chaos Center",
 				"step3":    "Start creating and running chaos experiments",
 			},
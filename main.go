@@ -5,14 +5,17 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
-	//"strconv"
+	"runtime/debug"
+	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 )
@@ -37,6 +40,13 @@ type MCPError struct {
 	Message string `json:"message"`
 }
 
+// MCPNotification is a server-initiated JSON-RPC notification.
+type MCPNotification struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+}
+
 // Tool definitions
 type Tool struct {
 	Name        string      `json:"name"`
@@ -46,6 +56,7 @@ type Tool struct {
 
 type ToolResult struct {
 	Content []ContentItem `json:"content"`
+	IsError bool          `json:"isError,omitempty"`
 }
 
 type ContentItem struct {
@@ -75,12 +86,67 @@ type LitmusConfig struct {
 	AccessToken            string
 	DefaultInfraID         string
 	DefaultEnvironmentID   string
+
+	// Resilience of requests to Chaos Center
+	RequestTimeout   time.Duration
+	MaxRetries       int
+	RetryBaseDelay   time.Duration
+	RetryMaxDelay    time.Duration
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+
+	// Login through the auth server instead of a static access token
+	AuthEndpoint    string
+	Username        string
+	Password        string
+	CredentialsFile string
+
+	// Named projects tools can select with their projectId argument
+	Projects []ProjectConfig
+
+	// Which tools are listed and may be called
+	ReadOnly     bool
+	AllowedTools []string
+	DeniedTools  []string
+
+	// Two-phase confirmation of runs on PROD infrastructure
+	ProdGuardrail   bool
+	ConfirmationTTL time.Duration
+
+	// Audit log of mutating tool calls
+	AuditLogPath      string
+	AuditLogMaxMB     int
+	AuditLogMaxFiles  int
+	AuditWebhookURL   string
+	AuditWebhookToken string
+
+	// Blackout windows in which chaos must not run
+	BlackoutFile string
+
+	// Admission policy that experiments are checked against before they are run or created
+	AdmissionPolicyFile string
+
+	// Spec directory that plan_chaos_changes and apply_chaos_changes read
+	SpecDir string
 }
 
 // Server struct
 type LitmusChaosServer struct {
-	config     *LitmusConfig
-	httpClient *http.Client
+	config        *LitmusConfig
+	httpClient    *http.Client
+	breaker       *circuitBreaker
+	auth          *tokenSource
+	projects      []*project
+	policy        *toolPolicy
+	confirmations *confirmationStore
+	audit         *auditLog
+	blackouts     *blackoutCalendar
+	admission     *admissionPolicy
+
+	clientsMu sync.Mutex
+	clients   map[string]*clientInfo
+	subscriptions *resourceSubscriptions
+	events        *liveEvents
 }
 
 // Initialize server
@@ -91,18 +157,83 @@ func NewLitmusChaosServer() *LitmusChaosServer {
 		AccessToken:          os.Getenv("LITMUS_ACCESS_TOKEN"),
 		DefaultInfraID:       os.Getenv("DEFAULT_INFRA_ID"),
 		DefaultEnvironmentID: getEnvOrDefault("DEFAULT_ENVIRONMENT_ID", "production"),
+		RequestTimeout:       getEnvDuration("LITMUS_REQUEST_TIMEOUT", defaultRequestTimeout),
+		MaxRetries:           getEnvInt("LITMUS_MAX_RETRIES", defaultMaxRetries),
+		RetryBaseDelay:       getEnvDuration("LITMUS_RETRY_BASE_DELAY", defaultRetryBaseDelay),
+		RetryMaxDelay:        getEnvDuration("LITMUS_RETRY_MAX_DELAY", defaultRetryMaxDelay),
+		BreakerThreshold:     getEnvInt("LITMUS_CIRCUIT_BREAKER_THRESHOLD", defaultBreakerThreshold),
+		BreakerCooldown:      getEnvDuration("LITMUS_CIRCUIT_BREAKER_COOLDOWN", defaultBreakerCooldown),
+		Username:             os.Getenv("LITMUS_USERNAME"),
+		Password:             os.Getenv("LITMUS_PASSWORD"),
+		CredentialsFile:      os.Getenv("LITMUS_CREDENTIALS_FILE"),
+		ReadOnly:             getEnvBool("LITMUS_MCP_READ_ONLY", false),
+		AllowedTools:         getEnvList("LITMUS_MCP_ALLOWED_TOOLS"),
+		DeniedTools:          getEnvList("LITMUS_MCP_DENIED_TOOLS"),
+		ProdGuardrail:        getEnvBool("LITMUS_PROD_GUARDRAIL", true),
+		ConfirmationTTL:      getEnvDuration("LITMUS_PROD_CONFIRMATION_TTL", defaultConfirmationTTL),
+		AuditLogPath:         os.Getenv("LITMUS_AUDIT_LOG"),
+		AuditLogMaxMB:        getEnvInt("LITMUS_AUDIT_LOG_MAX_MB", defaultAuditLogMaxMB),
+		AuditLogMaxFiles:     getEnvInt("LITMUS_AUDIT_LOG_MAX_FILES", defaultAuditLogMaxFiles),
+		AuditWebhookURL:      os.Getenv("LITMUS_AUDIT_WEBHOOK_URL"),
+		AuditWebhookToken:    os.Getenv("LITMUS_AUDIT_WEBHOOK_TOKEN"),
+		BlackoutFile:         os.Getenv("LITMUS_BLACKOUT_FILE"),
+		AdmissionPolicyFile:  os.Getenv("LITMUS_ADMISSION_POLICY_FILE"),
+		SpecDir:              os.Getenv("LITMUS_SPEC_DIR"),
 	}
+	config.AuthEndpoint = getEnvOrDefault("LITMUS_AUTH_ENDPOINT", defaultAuthEndpoint(config.ChaoscenterEndpoint))
 
+	projects, err := parseProjectConfigs(os.Getenv("LITMUS_PROJECTS"))
+	if err != nil {
+		log.Fatalf("Invalid LITMUS_PROJECTS: %v", err)
+	}
+	config.Projects = projects
+
+	// Without LITMUS_PROJECT_ID the first named project is the default
+	if config.ProjectID == "" && len(config.Projects) > 0 {
+		config.ProjectID = config.Projects[0].ProjectID
+	}
 	if config.ProjectID == "" {
-		log.Fatal("LITMUS_PROJECT_ID environment variable is required")
+		log.Fatal("LITMUS_PROJECT_ID or LITMUS_PROJECTS environment variable is required")
+	}
+	if config.Username != "" && config.Password == "" {
+		log.Fatal("LITMUS_PASSWORD environment variable is required when LITMUS_USERNAME is set")
 	}
 
-	return &LitmusChaosServer{
-		config: config,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+	httpClient := &http.Client{
+		Timeout: config.RequestTimeout,
 	}
+
+	server := &LitmusChaosServer{
+		config:        config,
+		httpClient:    httpClient,
+		breaker:       newCircuitBreaker(config.BreakerThreshold, config.BreakerCooldown),
+		auth:          newTokenSource(config, httpClient),
+		policy:        newToolPolicy(config.ReadOnly, config.AllowedTools, config.DeniedTools),
+		confirmations: newConfirmationStore(config.ConfirmationTTL),
+		clients:       make(map[string]*clientInfo),
+		subscriptions: newResourceSubscriptions(),
+	}
+	audit, err := newAuditLog(config)
+	if err != nil {
+		log.Fatalf("Audit log: %v", err)
+	}
+	server.audit = audit
+
+	blackouts, err := newBlackoutCalendar(config.BlackoutFile)
+	if err != nil {
+		log.Fatalf("Blackout windows: %v", err)
+	}
+	server.blackouts = blackouts
+
+	admission, err := newAdmissionPolicy(config.AdmissionPolicyFile)
+	if err != nil {
+		log.Fatalf("Admission policy: %v", err)
+	}
+	server.admission = admission
+	server.projects = newProjects(config, server.auth)
+	server.events = &liveEvents{server: server}
+
+	return server
 }
 
 func getEnvOrDefault(key, defaultValue string) string {
@@ -112,12 +243,67 @@ func getEnvOrDefault(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getEnvInt parses a non-negative integer from the environment, falling back to defaultValue if unset or invalid.
+func getEnvInt(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		log.Printf("Ignoring invalid %s=%q, using %d", key, value, defaultValue)
+		return defaultValue
+	}
+	return n
+}
+
+// getEnvBool parses a boolean such as "true" or "1" from the environment, falling back to defaultValue if unset or invalid.
+func getEnvBool(key string, defaultValue bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Ignoring invalid %s=%q, using %t", key, value, defaultValue)
+		return defaultValue
+	}
+	return b
+}
+
+// getEnvList splits a comma-separated list from the environment, dropping empty entries.
+func getEnvList(key string) []string {
+	var list []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// getEnvDuration parses a positive duration such as "30s" from the environment, falling back to defaultValue if unset or invalid.
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		log.Printf("Ignoring invalid %s=%q, using %s", key, value, defaultValue)
+		return defaultValue
+	}
+	return d
+}
+
 // GraphQL request helper
 func (s *LitmusChaosServer) graphqlRequest(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
 	if variables == nil {
 		variables = make(map[string]interface{})
 	}
-	variables["projectID"] = s.config.ProjectID
+	project := s.projectFromContext(ctx)
+	variables["projectID"] = project.ID
+	recordOperation(ctx, query)
 
 	reqBody := GraphQLRequest{
 		Query:     query,
@@ -129,6 +315,15 @@ func (s *LitmusChaosServer) graphqlRequest(ctx context.Context, query string, va
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
+	return s.withRetry(ctx, !isMutation(query), func() (json.RawMessage, error) {
+		return authenticatedAttempt(ctx, project.auth, func(token string) (json.RawMessage, error) {
+			return s.graphqlAttempt(ctx, jsonBody, token)
+		})
+	})
+}
+
+// graphqlAttempt sends a GraphQL request body to Chaos Center once.
+func (s *LitmusChaosServer) graphqlAttempt(ctx context.Context, jsonBody []byte, token string) (json.RawMessage, error) {
 	url := fmt.Sprintf("%s/query", s.config.ChaoscenterEndpoint)
 	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonBody))
 	if err != nil {
@@ -136,32 +331,47 @@ func (s *LitmusChaosServer) graphqlRequest(ctx context.Context, query string, va
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	if s.config.AccessToken != "" {
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.config.AccessToken))
+	if token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 	}
 
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		return nil, fmt.Errorf("%w: failed to execute request: %w", errUpstream, err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return nil, fmt.Errorf("%w: Chaos Center returned %s", errAuthFailed, resp.Status)
+	}
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, fmt.Errorf("%w: failed to read response: %w", errUpstream, err)
 	}
 
+	// Validation errors come back as GraphQL errors with a 4xx status; anything else that failed is reported by status
 	var gqlResp GraphQLResponse
-	if err := json.Unmarshal(body, &gqlResp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	parseErr := json.Unmarshal(body, &gqlResp)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		if parseErr != nil || len(gqlResp.Errors) == 0 || resp.StatusCode >= 500 {
+			return nil, fmt.Errorf("%w: %w", errUpstream, newHTTPStatusError(resp, body))
+		}
+	} else if parseErr != nil {
+		return nil, fmt.Errorf("%w: failed to unmarshal response: %v", errUpstream, parseErr)
 	}
 
 	if len(gqlResp.Errors) > 0 {
 		messages := make([]string, len(gqlResp.Errors))
+		auth := false
 		for i, e := range gqlResp.Errors {
 			messages[i] = e.Message
+			auth = auth || isAuthMessage(e.Message)
+		}
+		if auth {
+			return nil, fmt.Errorf("%w: %s", errAuthFailed, strings.Join(messages, ", "))
 		}
-		return nil, fmt.Errorf("GraphQL errors: %s", strings.Join(messages, ", "))
+		return nil, fmt.Errorf("%w: %s", errGraphQL, strings.Join(messages, ", "))
 	}
 
 	return gqlResp.Data, nil
@@ -170,6 +380,14 @@ func (s *LitmusChaosServer) graphqlRequest(ctx context.Context, query string, va
 // Tool definitions
 func (s *LitmusChaosServer) getTools() []Tool {
 	return []Tool{
+		{
+			Name:        "list_projects",
+			Description: "List the Chaos Center projects the server can act on, including named projects from its configuration",
+			InputSchema: map[string]interface{}{
+				"type":       "object",
+				"properties": map[string]interface{}{},
+			},
+		},
 		{
 			Name:        "list_chaos_experiments",
 			Description: "List all chaos experiments with optional filtering",
@@ -207,51 +425,200 @@ func (s *LitmusChaosServer) getTools() []Tool {
 				"required": []string{"experimentId"},
 			},
 		},
-		//{
-			//Name:        "create_chaos_experiment",
-			//Description: "Create a new chaos experiment using Chaos Studio",
-			//InputSchema: map[string]interface{}{
-				//"type": "object",
-				//"properties": map[string]interface{}{
-					//"name":        map[string]interface{}{"type": "string", "description": "Experiment name"},
-					//"description": map[string]interface{}{"type": "string", "description": "Experiment description"},
-					//"infraId":     map[string]interface{}{"type": "string", "description": "Infrastructure ID to run the experiment"},
-					//"faults": map[string]interface{}{
-						//"type": "array",
-						//"items": map[string]interface{}{
-							//"type": "object",
-							//"properties": map[string]interface{}{
-								//"name":       map[string]interface{}{"type": "string", "description": "Fault name (e.g., pod-delete, network-loss)"},
-								//"weight":     map[string]interface{}{"type": "number", "minimum": 1, "maximum": 10, "description": "Fault weight for scoring"},
-								//"targetApp":  map[string]interface{}{"type": "string", "description": "Target application selector"},
-								//"duration":   map[string]interface{}{"type": "string", "description": "Fault duration (e.g., 60s, 5m)"},
-								//"parameters": map[string]interface{}{"type": "object", "description": "Fault-specific parameters"},
-							//},
-							//"required": []string{"name", "targetApp"},
-						//},
-					//},
-					//"schedule": map[string]interface{}{
-						//"type": "object",
-						//"properties": map[string]interface{}{
-							//"cronExpression": map[string]interface{}{"type": "string", "description": "Cron expression for scheduling"},
-						//},
-					//},
-					//"tags": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}, "description": "Experiment tags"},
-				//},
-				//"required": []string{"name", "infraId", "faults"},
-			//},
-		//},
+		{
+			Name:        "create_chaos_experiment",
+			Description: "Create a new chaos experiment from ChaosHub faults, with ChaosEngine targets, probes and weightages",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name":        map[string]interface{}{"type": "string", "description": "Experiment name"},
+					"description": map[string]interface{}{"type": "string", "description": "Experiment description"},
+					"infraId":     map[string]interface{}{"type": "string", "description": "Infrastructure ID to run the experiment"},
+					"hubId":       map[string]interface{}{"type": "string", "description": "ChaosHub ID to take fault definitions from (defaults to the project's default hub)"},
+					"parallel":    map[string]interface{}{"type": "boolean", "description": "Run all faults in parallel instead of sequentially"},
+					"faults": map[string]interface{}{
+						"type": "array",
+						"items": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"name":         map[string]interface{}{"type": "string", "description": "Fault name (e.g., pod-delete, pod-network-loss)"},
+								"category":     map[string]interface{}{"type": "string", "description": "ChaosHub fault category (e.g., kubernetes); looked up when omitted"},
+								"weight":       map[string]interface{}{"type": "number", "minimum": 0, "maximum": 10, "description": "Fault weight for resiliency scoring (default 10)"},
+								"appNamespace": map[string]interface{}{"type": "string", "description": "Namespace of the target application"},
+								"appLabel":     map[string]interface{}{"type": "string", "description": "Label selector of the target application (e.g., app=nginx)"},
+								"appKind":      map[string]interface{}{"type": "string", "description": "Kind of the target application (e.g., deployment, statefulset)"},
+								"duration":     map[string]interface{}{"type": []string{"number", "string"}, "description": "Total chaos duration as whole seconds (e.g., 60) or a duration string (e.g., 90s, 5m)"},
+								"parameters":   map[string]interface{}{"type": "object", "description": "Fault-specific environment overrides (e.g., {\"PODS_AFFECTED_PERC\": \"50\"})"},
+								"probes": map[string]interface{}{
+									"type": "array",
+									"items": map[string]interface{}{
+										"type": "object",
+										"properties": map[string]interface{}{
+											"name": map[string]interface{}{"type": "string", "description": "Resilience probe name"},
+											"mode": map[string]interface{}{"type": "string", "enum": []string{"SOT", "EOT", "Edge", "Continuous", "OnChaos"}, "description": "Probe mode (default SOT)"},
+										},
+										"required": []string{"name"},
+									},
+									"description": "Resilience probes to attach to the fault",
+								},
+							},
+							"required": []string{"name"},
+						},
+					},
+					"schedule": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"cronExpression": map[string]interface{}{"type": "string", "description": "Cron expression for scheduling"},
+						},
+					},
+					"tags":              map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}, "description": "Experiment tags"},
+					"confirmationToken": map[string]interface{}{"type": "string", "description": "Token from the preview of a schedule on PROD infrastructure"},
+				},
+				"required": []string{"name", "infraId", "faults"},
+			},
+		},
+		{
+			Name:        "update_chaos_experiment",
+			Description: "Update the manifest, description, tags or schedule of a chaos experiment",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"experimentId":      map[string]interface{}{"type": "string", "description": "Experiment ID to update"},
+					"manifest":          map[string]interface{}{"type": "string", "description": "New Argo Workflow or CronWorkflow manifest as JSON or YAML"},
+					"description":       map[string]interface{}{"type": "string", "description": "New experiment description"},
+					"tags":              map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}, "description": "New experiment tags, replacing the current ones"},
+					"cronExpression":    map[string]interface{}{"type": "string", "description": "New cron schedule; an empty string removes the schedule"},
+					"confirmationToken": map[string]interface{}{"type": "string", "description": "Token from the preview of a schedule on PROD infrastructure"},
+				},
+				"required": []string{"experimentId"},
+			},
+		},
+		{
+			Name:        "delete_chaos_experiment",
+			Description: "Delete a chaos experiment",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"experimentId": map[string]interface{}{"type": "string", "description": "Experiment ID to delete"},
+				},
+				"required": []string{"experimentId"},
+			},
+		},
+		{
+			Name:        "clone_chaos_experiment",
+			Description: "Copy a chaos experiment under a new name, optionally onto a different infrastructure",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"experimentId":      map[string]interface{}{"type": "string", "description": "Experiment ID to copy"},
+					"name":              map[string]interface{}{"type": "string", "description": "Name of the copy (default: the original name with -copy appended)"},
+					"infraId":           map[string]interface{}{"type": "string", "description": "Infrastructure to run the copy on (default: the original's)"},
+					"description":       map[string]interface{}{"type": "string", "description": "Description of the copy"},
+					"tags":              map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}, "description": "Tags of the copy"},
+					"confirmationToken": map[string]interface{}{"type": "string", "description": "Token from the preview of a schedule on PROD infrastructure"},
+				},
+				"required": []string{"experimentId"},
+			},
+		},
+		{
+			Name:        "enable_experiment_schedule",
+			Description: "Resume the cron schedule of a chaos experiment. Schedules on PROD infrastructure first return a preview and a confirmation token; call again with the token to resume them",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"experimentId":      map[string]interface{}{"type": "string", "description": "Cron experiment ID"},
+					"confirmationToken": map[string]interface{}{"type": "string", "description": "Token from the preview of a schedule on PROD infrastructure"},
+				},
+				"required": []string{"experimentId"},
+			},
+		},
+		{
+			Name:        "disable_experiment_schedule",
+			Description: "Suspend the cron schedule of a chaos experiment without deleting it",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"experimentId": map[string]interface{}{"type": "string", "description": "Cron experiment ID"},
+				},
+				"required": []string{"experimentId"},
+			},
+		},
+		{
+			Name:        "export_chaos_experiment",
+			Description: "Export a chaos experiment as multi-document YAML (the Argo workflow followed by its ChaosEngines) without volatile fields, ready to keep in git",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"experimentId": map[string]interface{}{"type": "string", "description": "Experiment ID to export"},
+				},
+				"required": []string{"experimentId"},
+			},
+		},
+		{
+			Name:        "import_chaos_experiment",
+			Description: "Validate experiment YAML, as written by export_chaos_experiment, and create the experiment on an infrastructure or update it if it already exists there",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"yaml":              map[string]interface{}{"type": "string", "description": "Multi-document YAML with the Workflow or CronWorkflow and its ChaosEngines"},
+					"infraId":           map[string]interface{}{"type": "string", "description": "Infrastructure to create the experiment on (default: the updated experiment's, then DEFAULT_INFRA_ID)"},
+					"experimentId":      map[string]interface{}{"type": "string", "description": "Experiment to update (default: the experiment of the same name on the infrastructure, if any)"},
+					"name":              map[string]interface{}{"type": "string", "description": "Experiment name (default: the workflow's metadata.name)"},
+					"description":       map[string]interface{}{"type": "string", "description": "Experiment description"},
+					"tags":              map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}, "description": "Experiment tags"},
+					"confirmationToken": map[string]interface{}{"type": "string", "description": "Token from the preview of a schedule on PROD infrastructure"},
+				},
+				"required": []string{"yaml"},
+			},
+		},
+		{
+			Name:        "plan_chaos_changes",
+			Description: "Compare a spec directory of Environment, Probe and Experiment YAML with Chaos Center and list what apply_chaos_changes would create or update, without changing anything",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"dir": map[string]interface{}{"type": "string", "description": "Spec directory relative to LITMUS_SPEC_DIR, which must be set (default: LITMUS_SPEC_DIR itself)"},
+				},
+			},
+		},
+		{
+			Name:        "apply_chaos_changes",
+			Description: "Create and update environments, resilience probes and experiments until Chaos Center matches a spec directory. Resources the spec does not declare are left alone",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"dir":               map[string]interface{}{"type": "string", "description": "Spec directory relative to LITMUS_SPEC_DIR, which must be set (default: LITMUS_SPEC_DIR itself)"},
+					"confirmationToken": map[string]interface{}{"type": "string", "description": "Token from the preview of a schedule on PROD infrastructure"},
+				},
+			},
+		},
 		{
 			Name:        "run_chaos_experiment",
-			Description: "Execute a chaos experiment immediately",
+			Description: "Execute a chaos experiment immediately. Experiments targeting PROD infrastructure first return a preview and a confirmation token; call again with the token to run them",
 			InputSchema: map[string]interface{}{
 				"type": "object",
 				"properties": map[string]interface{}{
-					"experimentId": map[string]interface{}{"type": "string", "description": "Experiment ID to run"},
+					"experimentId":      map[string]interface{}{"type": "string", "description": "Experiment ID to run"},
+					"confirmationToken": map[string]interface{}{"type": "string", "description": "Token from the preview of a run targeting PROD infrastructure"},
 				},
 				"required": []string{"experimentId"},
 			},
 		},
+		{
+			Name:        "wait_for_experiment_run",
+			Description: "Start or attach to an experiment run and wait until it finishes, reporting fault progress; returns the resiliency score and fault summary",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"experimentId":        map[string]interface{}{"type": "string", "description": "Experiment to start and wait for (ignored when notifyId or experimentRunId is given)"},
+					"confirmationToken":   map[string]interface{}{"type": "string", "description": "Token from the preview of a run targeting PROD infrastructure"},
+					"notifyId":            map[string]interface{}{"type": "string", "description": "Notify ID returned by run_chaos_experiment"},
+					"experimentRunId":     map[string]interface{}{"type": "string", "description": "Existing experiment run to wait for"},
+					"timeoutSeconds":      map[string]interface{}{"type": "number", "minimum": 1, "maximum": 3600, "description": "Maximum time to wait (default 600)"},
+					"pollIntervalSeconds": map[string]interface{}{"type": "number", "minimum": 2, "description": "Interval between status checks (default 10)"},
+				},
+			},
+		},
 		{
 			Name:        "stop_chaos_experiment",
 			Description: "Stop a running chaos experiment",
@@ -266,7 +633,7 @@ func (s *LitmusChaosServer) getTools() []Tool {
 		},
 		{
 			Name:        "list_experiment_runs",
-			Description: "List experiment runs with detailed execution history",
+			Description: "List experiment runs with detailed execution history, a page at a time or all matching runs, filtered by experiment, status, infrastructure, creator and date range",
 			InputSchema: map[string]interface{}{
 				"type": "object",
 				"properties": map[string]interface{}{
@@ -276,7 +643,16 @@ func (s *LitmusChaosServer) getTools() []Tool {
 						"enum": []string{"Running", "Completed", "Failed", "Stopped", "Queued"},
 						"description": "Filter by run status",
 					},
-					"limit": map[string]interface{}{"type": "number", "minimum": 1, "maximum": 50, "description": "Number of runs to return"},
+					"infraId":    map[string]interface{}{"type": "string", "description": "Filter by infrastructure"},
+					"creator":    map[string]interface{}{"type": "string", "description": "Filter by the username that started the run"},
+					"startDate":  map[string]interface{}{"type": "string", "description": "Only runs updated at or after this time, as RFC 3339 or YYYY-MM-DD"},
+					"endDate":    map[string]interface{}{"type": "string", "description": "Only runs updated at or before this time, as RFC 3339 or YYYY-MM-DD (default: now)"},
+					"sortBy":     map[string]interface{}{"type": "string", "enum": []string{"time", "score"}, "description": "Sort by run time or resiliency score (default time)"},
+					"order":      map[string]interface{}{"type": "string", "enum": []string{"desc", "asc"}, "description": "Sort order (default desc, newest or highest first)"},
+					"page":       map[string]interface{}{"type": "number", "minimum": 0, "description": "Page to return, starting at 0"},
+					"limit":      map[string]interface{}{"type": "number", "minimum": 1, "maximum": 100, "description": "Number of runs per page (default 20)"},
+					"all":        map[string]interface{}{"type": "boolean", "description": "Return every matching run instead of a page, up to maxResults"},
+					"maxResults": map[string]interface{}{"type": "number", "minimum": 1, "maximum": 5000, "description": "Most runs to read with all, or when filtering by creator or sorting by score (default 500)"},
 				},
 			},
 		},
@@ -287,11 +663,61 @@ func (s *LitmusChaosServer) getTools() []Tool {
 				"type": "object",
 				"properties": map[string]interface{}{
 					"experimentRunId": map[string]interface{}{"type": "string", "description": "Experiment run ID"},
-					"includeLogs":     map[string]interface{}{"type": "boolean", "description": "Include execution logs"},
+					"includeLogs":     map[string]interface{}{"type": "boolean", "description": "Include the execution timeline: workflow steps with their timing, fault verdicts and probe statuses. Execution data that cannot be parsed is returned raw with executionError"},
 				},
 				"required": []string{"experimentRunId"},
 			},
 		},
+		{
+			Name:        "compare_experiment_runs",
+			Description: "Compare two experiment runs fault by fault: verdict, probe success percentage, duration and resiliency score contribution, highlighting regressions",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"baselineRunId":   map[string]interface{}{"type": "string", "description": "Experiment run to compare against, such as the last good run"},
+					"comparisonRunId": map[string]interface{}{"type": "string", "description": "Experiment run to check for regressions"},
+				},
+				"required": []string{"baselineRunId", "comparisonRunId"},
+			},
+		},
+		{
+			Name:        "get_fault_logs",
+			Description: "Fetch the logs of the pods that ran a fault in an experiment run, live from the run's chaos infrastructure, with tail and grep",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"experimentRunId": map[string]interface{}{"type": "string", "description": "Experiment run ID"},
+					"fault":           map[string]interface{}{"type": "string", "description": "Fault name, such as pod-delete, or the workflow step name if the fault runs in several steps"},
+					"pods": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string", "enum": []string{"experiment", "runner", "workflow"}},
+						"description": "Pods to return logs of: the experiment pod, which also reports on helper pods, the chaos runner, or the workflow step (default: experiment)",
+					},
+					"tail":           map[string]interface{}{"type": "number", "minimum": 1, "maximum": 5000, "description": "Number of lines to return from the end of each log (default 200)"},
+					"grep":           map[string]interface{}{"type": "string", "description": "Regular expression; only matching lines are returned, before tail is applied"},
+					"timeoutSeconds": map[string]interface{}{"type": "number", "minimum": 1, "maximum": 120, "description": "How long to wait for the infrastructure to send the logs (default 30)"},
+				},
+				"required": []string{"experimentRunId", "fault"},
+			},
+		},
+		{
+			Name:        "get_resilience_trends",
+			Description: "Resiliency score, pass rate, mean time between failures and flakiness over a time window, per experiment, infrastructure and environment, flagging statistically significant recent drops",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"days":          map[string]interface{}{"type": "number", "minimum": 1, "maximum": 365, "description": "Length of the window ending at endDate (default 30)"},
+					"startDate":     map[string]interface{}{"type": "string", "description": "Start of the window as RFC 3339 or YYYY-MM-DD (overrides days)"},
+					"endDate":       map[string]interface{}{"type": "string", "description": "End of the window as RFC 3339 or YYYY-MM-DD (default: now)"},
+					"recentDays":    map[string]interface{}{"type": "number", "minimum": 1, "description": "Runs in the last recentDays of the window are compared with the earlier ones to detect drops (default 7)"},
+					"bucket":        map[string]interface{}{"type": "string", "enum": []string{"day", "week"}, "description": "Period of the time series (default: day for windows up to 31 days, else week)"},
+					"groupBy":       map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string", "enum": []string{"experiment", "infrastructure", "environment"}}, "description": "Series to compute (default: all)"},
+					"experimentId":  map[string]interface{}{"type": "string", "description": "Only include runs of this experiment"},
+					"infraId":       map[string]interface{}{"type": "string", "description": "Only include runs on this infrastructure"},
+					"environmentId": map[string]interface{}{"type": "string", "description": "Only include runs on infrastructure in this environment"},
+				},
+			},
+		},
 		{
 			Name:        "list_chaos_infrastructures",
 			Description: "List all chaos infrastructures (formerly agents/delegates)",
@@ -458,25 +884,71 @@ func (s *LitmusChaosServer) handleTool(ctx context.Context, toolName string, arg
 	var args map[string]interface{}
 	if len(arguments) > 0 {
 		if err := json.Unmarshal(arguments, &args); err != nil {
-			return nil, fmt.Errorf("failed to parse arguments: %w", err)
+			return nil, fmt.Errorf("%w: failed to parse arguments: %v", errInvalidParams, err)
 		}
 	}
 
+	if _, known := toolCatalog[toolName]; !known {
+		return nil, fmt.Errorf("%w: %s", errUnknownTool, toolName)
+	}
+
+	if projectRef := getStringFromArgs(args, "projectId", ""); projectRef != "" {
+		ctx = withProject(ctx, s.resolveProject(projectRef))
+	}
+
+	return s.auditCall(ctx, toolName, args, func(ctx context.Context) (*ToolResult, error) {
+		if err := s.policy.check(toolName, args); err != nil {
+			return nil, err
+		}
+		return s.dispatchTool(ctx, toolName, args)
+	})
+}
+
+// dispatchTool runs the handler of a tool.
+func (s *LitmusChaosServer) dispatchTool(ctx context.Context, toolName string, args map[string]interface{}) (*ToolResult, error) {
 	switch toolName {
+	case "list_projects":
+		return s.listProjects(ctx, args)
 	case "list_chaos_experiments":
 		return s.listChaosExperiments(ctx, args)
 	case "get_chaos_experiment":
 		return s.getChaosExperiment(ctx, args)
-	//case "create_chaos_experiment":
-		//return s.createChaosExperiment(ctx, args)
+	case "create_chaos_experiment":
+		return s.createChaosExperiment(ctx, args)
+	case "update_chaos_experiment":
+		return s.updateChaosExperiment(ctx, args)
+	case "delete_chaos_experiment":
+		return s.deleteChaosExperiment(ctx, args)
+	case "clone_chaos_experiment":
+		return s.cloneChaosExperiment(ctx, args)
+	case "enable_experiment_schedule":
+		return s.enableExperimentSchedule(ctx, args)
+	case "disable_experiment_schedule":
+		return s.disableExperimentSchedule(ctx, args)
+	case "export_chaos_experiment":
+		return s.exportChaosExperiment(ctx, args)
+	case "import_chaos_experiment":
+		return s.importChaosExperiment(ctx, args)
+	case "plan_chaos_changes":
+		return s.planChaosAsCode(ctx, args)
+	case "apply_chaos_changes":
+		return s.applyChaosAsCode(ctx, args)
 	case "run_chaos_experiment":
 		return s.runChaosExperiment(ctx, args)
+	case "wait_for_experiment_run":
+		return s.waitForExperimentRun(ctx, args)
 	case "stop_chaos_experiment":
 		return s.stopChaosExperiment(ctx, args)
 	case "list_experiment_runs":
 		return s.listExperimentRuns(ctx, args)
 	case "get_experiment_run_details":
 		return s.getExperimentRunDetails(ctx, args)
+	case "compare_experiment_runs":
+		return s.compareExperimentRuns(ctx, args)
+	case "get_fault_logs":
+		return s.getFaultLogs(ctx, args)
+	case "get_resilience_trends":
+		return s.getResilienceTrends(ctx, args)
 	case "list_chaos_infrastructures":
 		return s.listChaosInfrastructures(ctx, args)
 	case "get_infrastructure_details":
@@ -498,14 +970,14 @@ func (s *LitmusChaosServer) handleTool(ctx context.Context, toolName string, arg
 	case "register_chaos_infrastructure":
 		return s.registerChaosInfrastructure(ctx, args)
 	default:
-		return nil, fmt.Errorf("unknown tool: %s", toolName)
+		return nil, fmt.Errorf("%w: %s", errUnknownTool, toolName)
 	}
 }
 
 // MCP Protocol handlers
 func (s *LitmusChaosServer) handleListTools() interface{} {
 	return map[string]interface{}{
-		"tools": s.getTools(),
+		"tools": withProjectArgument(s.policy.visibleTools(s.getTools())),
 	}
 }
 
@@ -513,25 +985,65 @@ func (s *LitmusChaosServer) handleCallTool(ctx context.Context, params json.RawM
 	var callParams struct {
 		Name      string          `json:"name"`
 		Arguments json.RawMessage `json:"arguments"`
+		Meta      struct {
+			ProgressToken interface{} `json:"progressToken"`
+		} `json:"_meta"`
 	}
 
 	if err := json.Unmarshal(params, &callParams); err != nil {
-		return nil, fmt.Errorf("failed to parse call tool params: %w", err)
+		return nil, fmt.Errorf("%w: failed to parse call tool params: %v", errInvalidParams, err)
+	}
+	if callParams.Name == "" {
+		return nil, fmt.Errorf("%w: tool name is required", errInvalidParams)
+	}
+
+	if callParams.Meta.ProgressToken != nil {
+		ctx = context.WithValue(ctx, progressTokenKey{}, callParams.Meta.ProgressToken)
 	}
 
 	result, err := s.handleTool(ctx, callParams.Name, callParams.Arguments)
 	if err != nil {
-		return nil, err
+		if isProtocolError(err) {
+			return nil, err
+		}
+		return toolError(err), nil
 	}
 
 	return result, nil
 }
 
-func (s *LitmusChaosServer) handleInitialize() interface{} {
+// Protocol revisions this server can speak, newest first
+var supportedProtocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+func (s *LitmusChaosServer) handleInitialize(ctx context.Context, params json.RawMessage) interface{} {
+	var initParams struct {
+		ProtocolVersion string     `json:"protocolVersion"`
+		ClientInfo      clientInfo `json:"clientInfo"`
+	}
+	if len(params) > 0 {
+		json.Unmarshal(params, &initParams)
+	}
+
+	if session := sessionFromContext(ctx); session != nil {
+		initParams.ClientInfo.ProtocolVersion = initParams.ProtocolVersion
+		s.rememberClient(session.id, initParams.ClientInfo)
+	}
+
+	// Echo the client's revision when supported, otherwise offer the latest one we speak
+	protocolVersion := supportedProtocolVersions[0]
+	for _, v := range supportedProtocolVersions {
+		if v == initParams.ProtocolVersion {
+			protocolVersion = v
+			break
+		}
+	}
+
 	return map[string]interface{}{
-		"protocolVersion": "2024-11-05",
+		"protocolVersion": protocolVersion,
 		"capabilities": map[string]interface{}{
-			"tools": map[string]interface{}{},
+			"tools":     map[string]interface{}{},
+			"resources": map[string]interface{}{"subscribe": true},
+			"prompts":   map[string]interface{}{},
 		},
 		"serverInfo": map[string]interface{}{
 			"name":    "litmuschaos-mcp-server",
@@ -541,91 +1053,396 @@ func (s *LitmusChaosServer) handleInitialize() interface{} {
 }
 
 // Main request handler
-func (s *LitmusChaosServer) handleRequest(ctx context.Context, req *MCPRequest) *MCPResponse {
-	resp := &MCPResponse{
+func (s *LitmusChaosServer) handleRequest(ctx context.Context, req *MCPRequest) (resp *MCPResponse) {
+	resp = &MCPResponse{
 		JSONRPC: "2.0",
 		ID:      req.ID,
 	}
 
+	// A bug in one handler must not take down the other requests sharing the process
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("panic while handling %s: %v\n%s", req.Method, r, debug.Stack())
+			if req.ID == nil {
+				resp = nil
+				return
+			}
+			resp = &MCPResponse{
+				JSONRPC: "2.0",
+				ID:      req.ID,
+				Error: &MCPError{
+					Code:    codeInternalError,
+					Message: fmt.Sprintf("internal error while handling %s: %v", req.Method, r),
+				},
+			}
+		}
+	}()
+
+	var result interface{}
+	var err error
+
 	switch req.Method {
 	case "initialize":
-		resp.Result = s.handleInitialize()
-	case "initialized":
+		result = s.handleInitialize(ctx, req.Params)
+	case "initialized", "notifications/initialized":
 		// No-op for initialized notification
 		return nil
 	case "tools/list":
-		resp.Result = s.handleListTools()
+		result = s.handleListTools()
 	case "tools/call":
-		result, err := s.handleCallTool(ctx, req.Params)
-		if err != nil {
-			resp.Error = &MCPError{
-				Code:    -32603,
-				Message: err.Error(),
-			}
-		} else {
-			resp.Result = result
-		}
+		result, err = s.handleCallTool(ctx, req.Params)
+	case "resources/list":
+		result, err = s.handleListResources(ctx)
+	case "resources/subscribe", "resources/unsubscribe":
+		result, err = s.handleSubscribeResource(ctx, req.Params, req.Method == "resources/subscribe")
+	case "resources/templates/list":
+		result = s.handleListResourceTemplates()
+	case "resources/read":
+		result, err = s.handleReadResource(ctx, req.Params)
+	case "prompts/list":
+		result = s.handleListPrompts()
+	case "prompts/get":
+		result, err = s.handleGetPrompt(ctx, req.Params)
 	default:
+		if req.ID == nil {
+			// Unknown notifications are ignored
+			return nil
+		}
 		resp.Error = &MCPError{
-			Code:    -32601,
+			Code:    codeMethodNotFound,
 			Message: fmt.Sprintf("Method not found: %s", req.Method),
 		}
+		return resp
+	}
+
+	if err != nil {
+		resp.Error = rpcError(err)
+	} else {
+		resp.Result = result
 	}
 
 	return resp
 }
 
+// notifyFunc delivers a server-initiated notification to the client that issued the current request.
+type notifyFunc func(msg *MCPNotification)
+
+type notifierKey struct{}
+
+type sessionKey struct{}
+
+type progressTokenKey struct{}
+
+// withNotifier attaches the transport's notification channel for the current request to ctx.
+func withNotifier(ctx context.Context, notify notifyFunc) context.Context {
+	return context.WithValue(ctx, notifierKey{}, notify)
+}
+
+// clientSession identifies the connected client across requests, for notifications that outlive a single request.
+type clientSession struct {
+	id     string
+	notify notifyFunc
+}
+
+// withSession attaches the client session issuing the current request to ctx.
+func withSession(ctx context.Context, id string, notify notifyFunc) context.Context {
+	return context.WithValue(ctx, sessionKey{}, &clientSession{id: id, notify: notify})
+}
+
+// sessionFromContext returns the client session of the current request, or nil if the transport has none.
+func sessionFromContext(ctx context.Context) *clientSession {
+	session, _ := ctx.Value(sessionKey{}).(*clientSession)
+	return session
+}
+
+// sendNotification sends a notification to the requesting client, if its transport supports it.
+func sendNotification(ctx context.Context, method string, params interface{}) {
+	notify, ok := ctx.Value(notifierKey{}).(notifyFunc)
+	if !ok {
+		return
+	}
+	notify(&MCPNotification{
+		JSONRPC: "2.0",
+		Method:  method,
+		Params:  params,
+	})
+}
+
+// reportProgress emits notifications/progress for the current tool call when the client asked for progress.
+func reportProgress(ctx context.Context, progress, total float64, message string) {
+	token := ctx.Value(progressTokenKey{})
+	if token == nil {
+		return
+	}
+
+	params := map[string]interface{}{
+		"progressToken": token,
+		"progress":      progress,
+	}
+	if total > 0 {
+		params["total"] = total
+	}
+	if message != "" {
+		params["message"] = message
+	}
+	sendNotification(ctx, "notifications/progress", params)
+}
+
+// Grace period for in-flight requests to finish once shutdown begins
+const shutdownGracePeriod = 30 * time.Second
+
+// requestTracker tracks in-flight requests so that they can be cancelled by ID and drained on shutdown.
+type requestTracker struct {
+	mu      sync.Mutex
+	cancels map[string]context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+func newRequestTracker() *requestTracker {
+	return &requestTracker{
+		cancels: make(map[string]context.CancelFunc),
+	}
+}
+
+// requestKey normalizes a JSON-RPC ID so numeric and string IDs from different messages compare equal.
+func requestKey(id interface{}) string {
+	key, _ := json.Marshal(id)
+	return string(key)
+}
+
+// begin registers a request and returns its context along with the function to call once it completes.
+func (t *requestTracker) begin(parent context.Context, id interface{}) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(parent)
+	key := requestKey(id)
+
+	t.mu.Lock()
+	t.cancels[key] = cancel
+	t.mu.Unlock()
+	t.wg.Add(1)
+
+	return ctx, func() {
+		t.mu.Lock()
+		delete(t.cancels, key)
+		t.mu.Unlock()
+		cancel()
+		t.wg.Done()
+	}
+}
+
+// cancel cancels the in-flight request with the given ID, reporting whether it was found.
+func (t *requestTracker) cancel(id interface{}) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	cancel, ok := t.cancels[requestKey(id)]
+	if ok {
+		cancel()
+	}
+	return ok
+}
+
+// cancelAll cancels every in-flight request.
+func (t *requestTracker) cancelAll() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, cancel := range t.cancels {
+		cancel()
+	}
+}
+
+// drain waits for in-flight requests to finish, cancelling whatever is still running after the timeout.
+func (t *requestTracker) drain(timeout time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		t.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return
+	case <-time.After(timeout):
+		log.Printf("In-flight requests did not finish within %s, cancelling them", timeout)
+		t.cancelAll()
+	}
+	<-done
+}
+
+// handleCancelled processes a notifications/cancelled message from the client.
+func (t *requestTracker) handleCancelled(params json.RawMessage) {
+	var cancelParams struct {
+		RequestID interface{} `json:"requestId"`
+		Reason    string      `json:"reason"`
+	}
+	if err := json.Unmarshal(params, &cancelParams); err != nil || cancelParams.RequestID == nil {
+		log.Printf("Ignoring malformed cancellation: %s", string(params))
+		return
+	}
+
+	if t.cancel(cancelParams.RequestID) {
+		log.Printf("Cancelled request %v: %s", cancelParams.RequestID, cancelParams.Reason)
+	}
+}
+
+// stdioWriter serializes JSON-RPC messages written to stdout by concurrent requests.
+type stdioWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (sw *stdioWriter) writeMessage(msg interface{}) {
+	msgJSON, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("Failed to marshal response: %v", err)
+		return
+	}
+
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	fmt.Fprintln(sw.w, string(msgJSON))
+}
+
 // Main server loop
-func (s *LitmusChaosServer) run() error {
-	scanner := bufio.NewScanner(os.Stdin)
-	
-	for scanner.Scan() {
-		line := scanner.Text()
-		if line == "" {
-			continue
-		}
+func (s *LitmusChaosServer) run(ctx context.Context) error {
+	s.events.bind(ctx)
+
+	lines := make(chan []byte)
+	scanErr := make(chan error, 1)
 
-		var req MCPRequest
-		if err := json.Unmarshal([]byte(line), &req); err != nil {
-			log.Printf("Failed to parse request: %v", err)
-			continue
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(os.Stdin)
+		scanner.Buffer(make([]byte, 64*1024), maxRequestBodyBytes)
+		for scanner.Scan() {
+			line := append([]byte(nil), scanner.Bytes()...)
+			select {
+			case lines <- line:
+			case <-ctx.Done():
+				return
+			}
 		}
+		scanErr <- scanner.Err()
+	}()
 
-		ctx := context.Background()
-		resp := s.handleRequest(ctx, &req)
-		
-		if resp != nil {
-			respJSON, err := json.Marshal(resp)
-			if err != nil {
-				log.Printf("Failed to marshal response: %v", err)
+	out := &stdioWriter{w: os.Stdout}
+	tracker := newRequestTracker()
+	sessionCtx := withSession(context.Background(), "stdio", func(msg *MCPNotification) {
+		out.writeMessage(msg)
+	})
+
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			break loop
+		case line, ok := <-lines:
+			if !ok {
+				break loop
+			}
+			if len(line) == 0 {
+				continue
+			}
+
+			var req MCPRequest
+			if err := json.Unmarshal(line, &req); err != nil {
+				log.Printf("Failed to parse request: %v", err)
+				out.writeMessage(&MCPResponse{
+					JSONRPC: "2.0",
+					Error:   &MCPError{Code: codeParseError, Message: "Parse error"},
+				})
 				continue
 			}
-			
-			fmt.Println(string(respJSON))
+
+			if req.Method == "notifications/cancelled" {
+				tracker.handleCancelled(req.Params)
+				continue
+			}
+
+			if req.ID == nil {
+				s.handleRequest(ctx, &req)
+				continue
+			}
+
+			reqCtx, done := tracker.begin(sessionCtx, req.ID)
+			reqCtx = withNotifier(reqCtx, func(msg *MCPNotification) {
+				out.writeMessage(msg)
+			})
+			go func(req *MCPRequest) {
+				defer done()
+
+				resp := s.handleRequest(reqCtx, req)
+				if reqCtx.Err() != nil {
+					// Cancelled requests get no response
+					return
+				}
+				if resp != nil {
+					out.writeMessage(resp)
+				}
+			}(&req)
 		}
 	}
 
-	return scanner.Err()
+	tracker.drain(shutdownGracePeriod)
+
+	select {
+	case err := <-scanErr:
+		return err
+	default:
+		return nil
+	}
 }
 
 func main() {
+	transport := flag.String("transport", getEnvOrDefault("MCP_TRANSPORT", "stdio"), "MCP transport to serve: stdio or http")
+	addr := flag.String("addr", getEnvOrDefault("MCP_HTTP_ADDR", "127.0.0.1:8000"), "Listen address for the http transport")
+	flag.Parse()
+
+	if flag.Arg(0) == "plan" {
+		os.Exit(runPlanCommand(flag.Args()[1:]))
+	}
+
 	server := NewLitmusChaosServer()
 
 	// Setup graceful shutdown
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 
 	go func() {
 		<-c
 		log.Println("Received shutdown signal, shutting down gracefully...")
-		os.Exit(0)
+		cancel()
 	}()
 
-	log.Printf("LitmusChaos MCP server v3.16.0 running on stdio")
 	log.Printf("Connected to Chaos Center: %s", server.config.ChaoscenterEndpoint)
 	log.Printf("Project ID: %s", server.config.ProjectID)
+	if server.config.ReadOnly {
+		log.Printf("Read-only mode: tools that change Chaos Center are disabled")
+	}
+	for _, p := range server.projects {
+		log.Printf("Project %s: %s", p.Name, p.ID)
+	}
 
-	if err := server.run(); err != nil {
+	var err error
+	switch *transport {
+	case "stdio":
+		log.Printf("LitmusChaos MCP server v3.16.0 running on stdio")
+		err = server.run(ctx)
+	case "http":
+		log.Printf("LitmusChaos MCP server v3.16.0 serving Streamable HTTP on http://%s%s", *addr, mcpEndpointPath)
+		err = server.runHTTP(ctx, HTTPTransportConfig{
+			Addr:           *addr,
+			AuthToken:      os.Getenv("MCP_HTTP_AUTH_TOKEN"),
+			AllowedOrigins: strings.Split(os.Getenv("MCP_HTTP_ALLOWED_ORIGINS"), ","),
+		})
+	default:
+		log.Fatalf("Unknown transport %q (expected stdio or http)", *transport)
+	}
+
+	if err != nil {
 		log.Fatalf("Server error: %v", err)
 	}
 }
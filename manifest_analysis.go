@@ -0,0 +1,271 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Env variables of Litmus faults that widen or narrow what a fault hits
+var blastRadiusEnv = []string{
+	"TOTAL_CHAOS_DURATION",
+	"PODS_AFFECTED_PERC",
+	"NODES_AFFECTED_PERC",
+	"TARGET_PODS",
+	"TARGET_NODES",
+	"NODE_LABEL",
+	"TARGET_CONTAINER",
+	"SEQUENCE",
+}
+
+// manifestFault is a ChaosEngine step of an experiment manifest.
+type manifestFault struct {
+	StepName     string            `json:"stepName"`
+	Fault        string            `json:"fault"`
+	AppNamespace string            `json:"appNamespace,omitempty"`
+	AppLabel     string            `json:"appLabel,omitempty"`
+	AppKind      string            `json:"appKind,omitempty"`
+	Env          map[string]string `json:"env,omitempty"`
+	Probes       []probeRef        `json:"probes,omitempty"`
+}
+
+// nodeLevel reports whether the fault acts on nodes rather than on pods of the target application.
+func (f *manifestFault) nodeLevel() bool {
+	return strings.HasPrefix(f.Fault, "node-")
+}
+
+// durationSeconds returns TOTAL_CHAOS_DURATION in seconds, and false if it is unset or not a number.
+func (f *manifestFault) durationSeconds() (int, bool) {
+	seconds, err := strconv.Atoi(strings.TrimSpace(f.Env["TOTAL_CHAOS_DURATION"]))
+	return seconds, err == nil
+}
+
+// experimentManifest is what the server reads from an experiment's Argo workflow manifest.
+type experimentManifest struct {
+	Kind      string
+	Name      string
+	Namespace string
+	Schedule  string
+	Faults    []manifestFault
+}
+
+// parseExperimentManifest reads the ChaosEngine steps of an experiment manifest, which Chaos Center stores
+// as a JSON or YAML Workflow or CronWorkflow with the engines embedded as raw artifacts.
+func parseExperimentManifest(manifest string) (*experimentManifest, error) {
+	var workflow struct {
+		Kind     string `json:"kind"`
+		Metadata struct {
+			Name         string `json:"name"`
+			GenerateName string `json:"generateName"`
+		} `json:"metadata"`
+		Spec struct {
+			workflowSpec
+			Schedule     string        `json:"schedule"`
+			WorkflowSpec *workflowSpec `json:"workflowSpec"`
+		} `json:"spec"`
+	}
+	if err := yaml.Unmarshal([]byte(manifest), &workflow); err != nil {
+		return nil, fmt.Errorf("failed to parse experiment manifest: %w", err)
+	}
+
+	spec := workflow.Spec.workflowSpec
+	if workflow.Spec.WorkflowSpec != nil {
+		spec = *workflow.Spec.WorkflowSpec
+	}
+
+	parsed := &experimentManifest{
+		Kind:     workflow.Kind,
+		Name:     workflow.Metadata.Name,
+		Schedule: workflow.Spec.Schedule,
+	}
+	if parsed.Name == "" {
+		parsed.Name = strings.TrimSuffix(workflow.Metadata.GenerateName, "-")
+	}
+	for _, param := range spec.Arguments.Parameters {
+		if param.Name == "adminModeNamespace" {
+			parsed.Namespace = param.Value
+		}
+	}
+
+	for _, template := range spec.Templates {
+		for _, artifact := range template.Inputs.Artifacts {
+			for _, doc := range splitYAMLDocuments(artifact.Raw.Data) {
+				fault, ok, err := parseChaosEngine(doc)
+				if err != nil {
+					return nil, fmt.Errorf("step %s: %w", template.Name, err)
+				}
+				if ok {
+					fault.StepName = template.Name
+					parsed.Faults = append(parsed.Faults, *fault)
+				}
+			}
+		}
+	}
+	return parsed, nil
+}
+
+// workflowSpec is the part of an Argo workflow spec that carries the fault steps.
+type workflowSpec struct {
+	Arguments struct {
+		Parameters []struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		} `json:"parameters"`
+	} `json:"arguments"`
+	Templates []struct {
+		Name   string `json:"name"`
+		Inputs struct {
+			Artifacts []struct {
+				Name string `json:"name"`
+				Raw  struct {
+					Data string `json:"data"`
+				} `json:"raw"`
+			} `json:"artifacts"`
+		} `json:"inputs"`
+	} `json:"templates"`
+}
+
+// splitYAMLDocuments splits a multi-document YAML string, dropping empty documents.
+func splitYAMLDocuments(data string) []string {
+	var docs []string
+	for _, doc := range strings.Split("\n"+data, "\n---") {
+		if strings.TrimSpace(doc) != "" {
+			docs = append(docs, doc)
+		}
+	}
+	return docs
+}
+
+// parseChaosEngine reads a ChaosEngine document, reporting false for documents of any other kind.
+func parseChaosEngine(doc string) (*manifestFault, bool, error) {
+	var engine struct {
+		Kind     string `json:"kind"`
+		Metadata struct {
+			Annotations map[string]string `json:"annotations"`
+		} `json:"metadata"`
+		Spec struct {
+			Appinfo struct {
+				Appns    string `json:"appns"`
+				Applabel string `json:"applabel"`
+				Appkind  string `json:"appkind"`
+			} `json:"appinfo"`
+			Experiments []struct {
+				Name string `json:"name"`
+				Spec struct {
+					Components struct {
+						Env []struct {
+							Name  string      `json:"name"`
+							Value interface{} `json:"value"`
+						} `json:"env"`
+					} `json:"components"`
+				} `json:"spec"`
+			} `json:"experiments"`
+		} `json:"spec"`
+	}
+	if err := yaml.Unmarshal([]byte(doc), &engine); err != nil {
+		return nil, false, fmt.Errorf("failed to parse ChaosEngine: %w", err)
+	}
+	if engine.Kind != "ChaosEngine" || len(engine.Spec.Experiments) == 0 {
+		return nil, false, nil
+	}
+
+	experiment := engine.Spec.Experiments[0]
+	fault := &manifestFault{
+		Fault:        experiment.Name,
+		AppNamespace: engine.Spec.Appinfo.Appns,
+		AppLabel:     engine.Spec.Appinfo.Applabel,
+		AppKind:      engine.Spec.Appinfo.Appkind,
+		Env:          map[string]string{},
+	}
+	for _, env := range experiment.Spec.Components.Env {
+		if env.Value != nil {
+			fault.Env[env.Name] = fmt.Sprint(env.Value)
+		}
+	}
+	if refs := engine.Metadata.Annotations["probeRef"]; refs != "" {
+		if err := json.Unmarshal([]byte(refs), &fault.Probes); err != nil {
+			return nil, false, fmt.Errorf("invalid probeRef annotation on %s: %w", fault.Fault, err)
+		}
+	}
+	return fault, true, nil
+}
+
+// blastRadius summarizes what the faults of a manifest can affect.
+func (m *experimentManifest) blastRadius() map[string]interface{} {
+	namespaces := map[string]bool{}
+	nodeFaults := []string{}
+	maxDuration := 0
+	totalDuration := 0
+
+	faults := make([]map[string]interface{}, len(m.Faults))
+	for i, fault := range m.Faults {
+		target := map[string]interface{}{}
+		if fault.AppNamespace != "" {
+			target["namespace"] = fault.AppNamespace
+			namespaces[fault.AppNamespace] = true
+		}
+		if fault.AppLabel != "" {
+			target["label"] = fault.AppLabel
+		}
+		if fault.AppKind != "" {
+			target["kind"] = fault.AppKind
+		}
+
+		settings := map[string]string{}
+		for _, name := range blastRadiusEnv {
+			if value := fault.Env[name]; value != "" {
+				settings[name] = value
+			}
+		}
+
+		if fault.nodeLevel() {
+			nodeFaults = append(nodeFaults, fault.Fault)
+		}
+		if seconds, ok := fault.durationSeconds(); ok {
+			totalDuration += seconds
+			if seconds > maxDuration {
+				maxDuration = seconds
+			}
+		}
+
+		faults[i] = map[string]interface{}{
+			"fault":     fault.Fault,
+			"step":      fault.StepName,
+			"target":    target,
+			"settings":  settings,
+			"nodeLevel": fault.nodeLevel(),
+			"probes":    len(fault.Probes),
+		}
+	}
+
+	namespaceList := make([]string, 0, len(namespaces))
+	for namespace := range namespaces {
+		namespaceList = append(namespaceList, namespace)
+	}
+	sort.Strings(namespaceList)
+
+	return map[string]interface{}{
+		"faults":              faults,
+		"targetNamespaces":    namespaceList,
+		"nodeLevelFaults":     nodeFaults,
+		"longestFaultSeconds": maxDuration,
+		"totalFaultSeconds":   totalDuration,
+		"chaosNamespace":      m.Namespace,
+		"faultsWithoutProbes": faultsWithoutProbes(m.Faults),
+	}
+}
+
+// faultsWithoutProbes lists faults that run without any resilience probe checking steady state.
+func faultsWithoutProbes(faults []manifestFault) []string {
+	names := []string{}
+	for _, fault := range faults {
+		if len(fault.Probes) == 0 {
+			names = append(names, fault.Fault)
+		}
+	}
+	return names
+}
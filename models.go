@@ -0,0 +1,468 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// errMalformedResponse is returned when Chaos Center answers with data that does not match the expected schema.
+var errMalformedResponse = errors.New("malformed GraphQL response")
+
+// errMissingField marks malformed responses whose root field is absent or null, as for objects that do not exist.
+var errMissingField = errors.New("missing or null")
+
+// validator is implemented by models that have fields the handlers cannot work without.
+type validator interface {
+	validate() error
+}
+
+// decodeField unmarshals the root field of a GraphQL data payload into out.
+// A missing or null field, a type mismatch or a failed validation is reported as errMalformedResponse.
+func decodeField(data json.RawMessage, field string, out interface{}) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return fmt.Errorf("%w: %v", errMalformedResponse, err)
+	}
+
+	raw, ok := fields[field]
+	if !ok || string(raw) == "null" {
+		return fmt.Errorf("%w: %s is %w", errMalformedResponse, field, errMissingField)
+	}
+
+	if err := json.Unmarshal(raw, out); err != nil {
+		return fmt.Errorf("%w: %s: %v", errMalformedResponse, field, err)
+	}
+
+	if v, ok := out.(validator); ok {
+		if err := v.validate(); err != nil {
+			return fmt.Errorf("%w: %s: %v", errMalformedResponse, field, err)
+		}
+	}
+
+	return nil
+}
+
+// query runs a GraphQL operation and decodes its root field into out.
+func (s *LitmusChaosServer) query(ctx context.Context, query string, variables map[string]interface{}, field string, out interface{}) error {
+	data, err := s.graphqlRequest(ctx, query, variables)
+	if err != nil {
+		return err
+	}
+	return decodeField(data, field, out)
+}
+
+// requireFields reports the first of the named values that is empty.
+func requireFields(fields ...string) error {
+	for i := 0; i+1 < len(fields); i += 2 {
+		if fields[i+1] == "" {
+			return fmt.Errorf("%s is missing or null", fields[i])
+		}
+	}
+	return nil
+}
+
+// UserDetails identifies the user that created or updated a resource.
+type UserDetails struct {
+	Username string `json:"username"`
+	Email    string `json:"email,omitempty"`
+}
+
+// name returns the username, or "" if no user is recorded.
+func (u *UserDetails) name() string {
+	if u == nil {
+		return ""
+	}
+	return u.Username
+}
+
+// Weightage is the weight of a fault in an experiment's resiliency score.
+type Weightage struct {
+	FaultName string `json:"faultName"`
+	Weightage int    `json:"weightage"`
+}
+
+// RecentExperimentRun summarizes one of the latest runs of an experiment.
+type RecentExperimentRun struct {
+	ExperimentRunID string   `json:"experimentRunID"`
+	Phase           string   `json:"phase"`
+	ResiliencyScore *float64 `json:"resiliencyScore"`
+	UpdatedAt       string   `json:"updatedAt"`
+	RunSequence     int      `json:"runSequence"`
+}
+
+// Experiment is a chaos experiment as returned by listExperiment and getExperiment.
+type Experiment struct {
+	ProjectID                  string                `json:"projectID,omitempty"`
+	ExperimentID               string                `json:"experimentID"`
+	Name                       string                `json:"name"`
+	Description                string                `json:"description,omitempty"`
+	ExperimentManifest         string                `json:"experimentManifest,omitempty"`
+	ExperimentType             string                `json:"experimentType,omitempty"`
+	CronSyntax                 string                `json:"cronSyntax,omitempty"`
+	IsCustomExperiment         bool                  `json:"isCustomExperiment"`
+	Weightages                 []Weightage           `json:"weightages,omitempty"`
+	Tags                       []string              `json:"tags,omitempty"`
+	Infra                      *Infra                `json:"infra,omitempty"`
+	RecentExperimentRunDetails []RecentExperimentRun `json:"recentExperimentRunDetails,omitempty"`
+	CreatedBy                  *UserDetails          `json:"createdBy,omitempty"`
+	UpdatedBy                  *UserDetails          `json:"updatedBy,omitempty"`
+	CreatedAt                  string                `json:"createdAt,omitempty"`
+	UpdatedAt                  string                `json:"updatedAt,omitempty"`
+}
+
+func (e *Experiment) validate() error {
+	return requireFields("experimentID", e.ExperimentID)
+}
+
+// ListExperimentResponse is the payload of listExperiment.
+type ListExperimentResponse struct {
+	TotalNoOfExperiments int          `json:"totalNoOfExperiments"`
+	Experiments          []Experiment `json:"experiments"`
+}
+
+func (r *ListExperimentResponse) validate() error {
+	for i := range r.Experiments {
+		if err := r.Experiments[i].validate(); err != nil {
+			return fmt.Errorf("experiments[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// GetExperimentResponse is the payload of getExperiment.
+type GetExperimentResponse struct {
+	ExperimentDetails      *Experiment `json:"experimentDetails"`
+	AverageResiliencyScore *float64    `json:"averageResiliencyScore"`
+}
+
+func (r *GetExperimentResponse) validate() error {
+	if r.ExperimentDetails == nil {
+		return fmt.Errorf("experimentDetails is missing or null")
+	}
+	return r.ExperimentDetails.validate()
+}
+
+// ChaosExperimentResponse is the payload of createChaosExperiment.
+type ChaosExperimentResponse struct {
+	ExperimentID          string   `json:"experimentID"`
+	ExperimentName        string   `json:"experimentName"`
+	ExperimentDescription string   `json:"experimentDescription"`
+	CronSyntax            string   `json:"cronSyntax"`
+	IsCustomExperiment    bool     `json:"isCustomExperiment"`
+	Tags                  []string `json:"tags"`
+}
+
+func (r *ChaosExperimentResponse) validate() error {
+	return requireFields("experimentID", r.ExperimentID)
+}
+
+// RunChaosExperimentResponse is the payload of runChaosExperiment.
+type RunChaosExperimentResponse struct {
+	NotifyID string `json:"notifyID"`
+}
+
+func (r *RunChaosExperimentResponse) validate() error {
+	return requireFields("notifyID", r.NotifyID)
+}
+
+// ExperimentRun is a single run of a chaos experiment.
+type ExperimentRun struct {
+	ProjectID          string       `json:"projectID,omitempty"`
+	ExperimentRunID    string       `json:"experimentRunID"`
+	ExperimentID       string       `json:"experimentID"`
+	ExperimentName     string       `json:"experimentName"`
+	ExperimentManifest string       `json:"experimentManifest,omitempty"`
+	Phase              string       `json:"phase"`
+	ResiliencyScore    *float64     `json:"resiliencyScore"`
+	FaultsPassed       int          `json:"faultsPassed"`
+	FaultsFailed       int          `json:"faultsFailed"`
+	FaultsAwaited      int          `json:"faultsAwaited"`
+	FaultsStopped      int          `json:"faultsStopped"`
+	FaultsNa           int          `json:"faultsNa"`
+	TotalFaults        int          `json:"totalFaults"`
+	ExecutionData      string       `json:"executionData,omitempty"`
+	RunSequence        int          `json:"runSequence,omitempty"`
+	Infra              *Infra       `json:"infra,omitempty"`
+	CreatedBy          *UserDetails `json:"createdBy,omitempty"`
+	UpdatedBy          *UserDetails `json:"updatedBy,omitempty"`
+	CreatedAt          string       `json:"createdAt,omitempty"`
+	UpdatedAt          string       `json:"updatedAt,omitempty"`
+}
+
+func (r *ExperimentRun) validate() error {
+	return requireFields("experimentID", r.ExperimentID)
+}
+
+// ListExperimentRunResponse is the payload of listExperimentRun.
+type ListExperimentRunResponse struct {
+	TotalNoOfExperimentRuns int             `json:"totalNoOfExperimentRuns"`
+	ExperimentRuns          []ExperimentRun `json:"experimentRuns"`
+}
+
+func (r *ListExperimentRunResponse) validate() error {
+	for i := range r.ExperimentRuns {
+		if err := r.ExperimentRuns[i].validate(); err != nil {
+			return fmt.Errorf("experimentRuns[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Infra is a chaos infrastructure connected to Chaos Center.
+type Infra struct {
+	ProjectID               string       `json:"projectID,omitempty"`
+	InfraID                 string       `json:"infraID"`
+	Name                    string       `json:"name"`
+	Description             string       `json:"description,omitempty"`
+	EnvironmentID           string       `json:"environmentID,omitempty"`
+	PlatformName            string       `json:"platformName,omitempty"`
+	IsActive                bool         `json:"isActive"`
+	IsInfraConfirmed        bool         `json:"isInfraConfirmed"`
+	InfraScope              string       `json:"infraScope,omitempty"`
+	InfraNamespace          string       `json:"infraNamespace,omitempty"`
+	ServiceAccount          string       `json:"serviceAccount,omitempty"`
+	InfraNsExists           *bool        `json:"infraNsExists,omitempty"`
+	InfraSaExists           *bool        `json:"infraSaExists,omitempty"`
+	Version                 string       `json:"version,omitempty"`
+	Token                   string       `json:"token,omitempty"`
+	NoOfExperiments         int          `json:"noOfExperiments,omitempty"`
+	NoOfExperimentRuns      int          `json:"noOfExperimentRuns,omitempty"`
+	LastExperimentTimestamp string       `json:"lastExperimentTimestamp,omitempty"`
+	StartTime               string       `json:"startTime,omitempty"`
+	Tags                    []string     `json:"tags,omitempty"`
+	UpdateStatus            string       `json:"updateStatus,omitempty"`
+	CreatedBy               *UserDetails `json:"createdBy,omitempty"`
+	UpdatedBy               *UserDetails `json:"updatedBy,omitempty"`
+	CreatedAt               string       `json:"createdAt,omitempty"`
+	UpdatedAt               string       `json:"updatedAt,omitempty"`
+}
+
+func (i *Infra) validate() error {
+	return requireFields("infraID", i.InfraID)
+}
+
+// ListInfraResponse is the payload of listInfras.
+type ListInfraResponse struct {
+	TotalNoOfInfras int     `json:"totalNoOfInfras"`
+	Infras          []Infra `json:"infras"`
+}
+
+func (r *ListInfraResponse) validate() error {
+	for i := range r.Infras {
+		if err := r.Infras[i].validate(); err != nil {
+			return fmt.Errorf("infras[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// RegisterInfraResponse is the payload of registerInfra.
+type RegisterInfraResponse struct {
+	Token    string `json:"token"`
+	InfraID  string `json:"infraID"`
+	Name     string `json:"name"`
+	Manifest string `json:"manifest"`
+}
+
+func (r *RegisterInfraResponse) validate() error {
+	return requireFields("infraID", r.InfraID, "manifest", r.Manifest)
+}
+
+// InfraEvent is a connection state change of a chaos infrastructure, delivered by getInfraEvents.
+type InfraEvent struct {
+	EventID   string `json:"eventID"`
+	EventType string `json:"eventType"`
+	EventName string `json:"eventName"`
+	Infra     *Infra `json:"infra,omitempty"`
+}
+
+// Environment groups chaos infrastructures, e.g. by PROD and NON_PROD.
+type Environment struct {
+	ProjectID     string       `json:"projectID,omitempty"`
+	EnvironmentID string       `json:"environmentID"`
+	Name          string       `json:"name"`
+	Description   string       `json:"description,omitempty"`
+	Type          string       `json:"type"`
+	Tags          []string     `json:"tags,omitempty"`
+	InfraIDs      []string     `json:"infraIDs,omitempty"`
+	CreatedBy     *UserDetails `json:"createdBy,omitempty"`
+	UpdatedBy     *UserDetails `json:"updatedBy,omitempty"`
+	CreatedAt     string       `json:"createdAt,omitempty"`
+	UpdatedAt     string       `json:"updatedAt,omitempty"`
+}
+
+func (e *Environment) validate() error {
+	return requireFields("environmentID", e.EnvironmentID)
+}
+
+// ListEnvironmentResponse is the payload of listEnvironments.
+type ListEnvironmentResponse struct {
+	TotalNoOfEnvironments int           `json:"totalNoOfEnvironments"`
+	Environments          []Environment `json:"environments"`
+}
+
+func (r *ListEnvironmentResponse) validate() error {
+	for i := range r.Environments {
+		if err := r.Environments[i].validate(); err != nil {
+			return fmt.Errorf("environments[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Probe is a resilience probe that can be attached to faults.
+type Probe struct {
+	ProjectID          string       `json:"projectID,omitempty"`
+	Name               string       `json:"name"`
+	Description        string       `json:"description,omitempty"`
+	Type               string       `json:"type"`
+	InfrastructureType string       `json:"infrastructureType,omitempty"`
+	Tags               []string     `json:"tags,omitempty"`
+	ReferencedBy       *int         `json:"referencedBy,omitempty"`
+	CreatedBy          *UserDetails `json:"createdBy,omitempty"`
+	UpdatedBy          *UserDetails `json:"updatedBy,omitempty"`
+	CreatedAt          string       `json:"createdAt,omitempty"`
+	UpdatedAt          string       `json:"updatedAt,omitempty"`
+}
+
+func (p *Probe) validate() error {
+	return requireFields("name", p.Name, "type", p.Type)
+}
+
+// ProbeList is the payload of listProbes.
+type ProbeList []Probe
+
+func (l ProbeList) validate() error {
+	for i := range l {
+		if err := l[i].validate(); err != nil {
+			return fmt.Errorf("[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// ChaosHub is a Git or remote repository of chaos faults.
+type ChaosHub struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	Description      string       `json:"description,omitempty"`
+	RepoURL          string       `json:"repoURL,omitempty"`
+	RepoBranch       string       `json:"repoBranch,omitempty"`
+	RemoteHub        string       `json:"remoteHub,omitempty"`
+	HubType          string       `json:"hubType,omitempty"`
+	IsPrivate        bool         `json:"isPrivate"`
+	IsAvailable      bool         `json:"isAvailable"`
+	IsDefault        bool         `json:"isDefault"`
+	TotalFaults      string       `json:"totalFaults,omitempty"`
+	TotalExperiments string       `json:"totalExperiments,omitempty"`
+	Tags             []string     `json:"tags,omitempty"`
+	LastSyncedAt     string       `json:"lastSyncedAt,omitempty"`
+	CreatedBy        *UserDetails `json:"createdBy,omitempty"`
+	UpdatedBy        *UserDetails `json:"updatedBy,omitempty"`
+	CreatedAt        string       `json:"createdAt,omitempty"`
+	UpdatedAt        string       `json:"updatedAt,omitempty"`
+}
+
+func (h *ChaosHub) validate() error {
+	return requireFields("id", h.ID)
+}
+
+// ChaosHubList is the payload of listChaosHub.
+type ChaosHubList []ChaosHub
+
+func (l ChaosHubList) validate() error {
+	for i := range l {
+		if err := l[i].validate(); err != nil {
+			return fmt.Errorf("[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// FaultList is a fault offered by a ChaosHub category.
+type FaultList struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// FaultCategory is a ChaosHub chart grouping related faults.
+type FaultCategory struct {
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+	Metadata   struct {
+		Name        string `json:"name"`
+		Version     string `json:"version,omitempty"`
+		Annotations *struct {
+			Categories string `json:"categories,omitempty"`
+			Vendor     string `json:"vendor,omitempty"`
+			Repository string `json:"repository,omitempty"`
+		} `json:"annotations,omitempty"`
+	} `json:"metadata"`
+	Spec struct {
+		DisplayName         string      `json:"displayName,omitempty"`
+		CategoryDescription string      `json:"categoryDescription,omitempty"`
+		Keywords            []string    `json:"keywords,omitempty"`
+		Maturity            string      `json:"maturity,omitempty"`
+		Platforms           []string    `json:"platforms,omitempty"`
+		ChaosType           string      `json:"chaosType,omitempty"`
+		Faults              []FaultList `json:"faults"`
+	} `json:"spec"`
+}
+
+func (c *FaultCategory) validate() error {
+	if err := requireFields("metadata.name", c.Metadata.Name); err != nil {
+		return err
+	}
+	for i, fault := range c.Spec.Faults {
+		if err := requireFields("name", fault.Name); err != nil {
+			return fmt.Errorf("spec.faults[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// FaultCategoryList is the payload of listChaosFaults.
+type FaultCategoryList []FaultCategory
+
+func (l FaultCategoryList) validate() error {
+	for i := range l {
+		if err := l[i].validate(); err != nil {
+			return fmt.Errorf("[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// ResiliencyScoreCategory counts experiments within a resiliency score bucket.
+type ResiliencyScoreCategory struct {
+	ID    int `json:"id"`
+	Count int `json:"count"`
+}
+
+// ExperimentStats is the payload of getExperimentStats.
+type ExperimentStats struct {
+	TotalExperiments                     int                       `json:"totalExperiments"`
+	TotalExpCategorizedByResiliencyScore []ResiliencyScoreCategory `json:"totalExpCategorizedByResiliencyScore"`
+}
+
+// ExperimentRunStats is the payload of getExperimentRunStats.
+type ExperimentRunStats struct {
+	TotalExperimentRuns           int `json:"totalExperimentRuns"`
+	TotalCompletedExperimentRuns  int `json:"totalCompletedExperimentRuns"`
+	TotalTerminatedExperimentRuns int `json:"totalTerminatedExperimentRuns"`
+	TotalRunningExperimentRuns    int `json:"totalRunningExperimentRuns"`
+	TotalStoppedExperimentRuns    int `json:"totalStoppedExperimentRuns"`
+	TotalErroredExperimentRuns    int `json:"totalErroredExperimentRuns"`
+}
+
+// InfraStats is the payload of getInfraStats.
+type InfraStats struct {
+	TotalInfrastructures             int `json:"totalInfrastructures"`
+	TotalActiveInfrastructure        int `json:"totalActiveInfrastructure"`
+	TotalInactiveInfrastructures     int `json:"totalInactiveInfrastructures"`
+	TotalConfirmedInfrastructure     int `json:"totalConfirmedInfrastructure"`
+	TotalNonConfirmedInfrastructures int `json:"totalNonConfirmedInfrastructures"`
+}
@@ -0,0 +1,296 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// project is a Chaos Center project the server acts on, together with the credentials used for it.
+type project struct {
+	Name string
+	ID   string
+	auth *tokenSource
+}
+
+// ProjectConfig is a named project from LITMUS_PROJECTS, optionally with an access token of its own.
+type ProjectConfig struct {
+	Name        string
+	ProjectID   string
+	AccessToken string
+}
+
+// parseProjectConfigs parses LITMUS_PROJECTS, a comma-separated list of name=projectID or name=projectID:accessToken.
+func parseProjectConfigs(spec string) ([]ProjectConfig, error) {
+	var projects []ProjectConfig
+	seen := map[string]bool{}
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid project %q, expected name=projectID[:accessToken]", entry)
+		}
+		id, token, _ := strings.Cut(strings.TrimSpace(value), ":")
+		if id == "" {
+			return nil, fmt.Errorf("project %q has no project ID", name)
+		}
+		if seen[strings.ToLower(name)] {
+			return nil, fmt.Errorf("project %q is configured twice", name)
+		}
+		seen[strings.ToLower(name)] = true
+
+		projects = append(projects, ProjectConfig{Name: name, ProjectID: id, AccessToken: token})
+	}
+	return projects, nil
+}
+
+// newProjects builds the configured projects. Projects without an access token of their own use the server's credentials.
+func newProjects(config *LitmusConfig, auth *tokenSource) []*project {
+	projects := make([]*project, len(config.Projects))
+	for i, pc := range config.Projects {
+		projects[i] = &project{Name: pc.Name, ID: pc.ProjectID, auth: auth}
+		if pc.AccessToken != "" {
+			projects[i].auth = newStaticTokenSource(pc.AccessToken)
+		}
+	}
+	return projects
+}
+
+type projectKey struct{}
+
+// withProject directs the GraphQL requests made with ctx at p instead of the default project.
+func withProject(ctx context.Context, p *project) context.Context {
+	return context.WithValue(ctx, projectKey{}, p)
+}
+
+// projectFromContext returns the project selected for the current tool call, or the default project.
+func (s *LitmusChaosServer) projectFromContext(ctx context.Context) *project {
+	if p, ok := ctx.Value(projectKey{}).(*project); ok {
+		return p
+	}
+	return s.resolveProject(s.config.ProjectID)
+}
+
+// resolveProject finds a project by configured name or project ID. Unknown IDs are used as is with the server's
+// credentials, so projects the user is a member of work without being configured.
+func (s *LitmusChaosServer) resolveProject(ref string) *project {
+	for _, p := range s.projects {
+		if strings.EqualFold(p.Name, ref) {
+			return p
+		}
+	}
+	for _, p := range s.projects {
+		if p.ID == ref {
+			return p
+		}
+	}
+	return &project{ID: ref, auth: s.auth}
+}
+
+// projectArgument is added to the input schema of every tool.
+var projectArgument = map[string]interface{}{
+	"type":        "string",
+	"description": "Project ID or configured project name to act on instead of the default project",
+}
+
+// withProjectArgument adds the optional projectId argument to the input schemas of project-scoped tools.
+func withProjectArgument(tools []Tool) []Tool {
+	for _, tool := range tools {
+		schema, ok := tool.InputSchema.(map[string]interface{})
+		if !ok || tool.Name == "list_projects" {
+			continue
+		}
+		properties, ok := schema["properties"].(map[string]interface{})
+		if !ok {
+			properties = map[string]interface{}{}
+			schema["properties"] = properties
+		}
+		if _, exists := properties["projectId"]; !exists {
+			properties["projectId"] = projectArgument
+		}
+	}
+	return tools
+}
+
+// AuthProject is a project as returned by the auth server's project API.
+type AuthProject struct {
+	ProjectID   string          `json:"projectID"`
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Tags        []string        `json:"tags,omitempty"`
+	State       *string         `json:"state,omitempty"`
+	Members     []ProjectMember `json:"members"`
+}
+
+func (p *AuthProject) validate() error {
+	return requireFields("projectID", p.ProjectID)
+}
+
+// ProjectMember is a user's membership in a project.
+type ProjectMember struct {
+	UserID     string `json:"userID"`
+	Username   string `json:"username"`
+	Role       string `json:"role"`
+	Invitation string `json:"invitation"`
+}
+
+// authServerRequest sends a GET request to the auth server with the credentials of auth and returns its data field.
+func (s *LitmusChaosServer) authServerRequest(ctx context.Context, auth *tokenSource, path string) (json.RawMessage, error) {
+	url := strings.TrimRight(s.config.AuthEndpoint, "/") + path
+
+	return s.withRetry(ctx, true, func() (json.RawMessage, error) {
+		return authenticatedAttempt(ctx, auth, func(token string) (json.RawMessage, error) {
+			req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			if token != "" {
+				req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+			}
+
+			resp, err := s.httpClient.Do(req)
+			if err != nil {
+				return nil, fmt.Errorf("%w: failed to execute request: %w", errUpstream, err)
+			}
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, fmt.Errorf("%w: failed to read response: %w", errUpstream, err)
+			}
+
+			switch {
+			case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+				return nil, fmt.Errorf("%w: auth server returned %s", errAuthFailed, resp.Status)
+			case resp.StatusCode < 200 || resp.StatusCode > 299:
+				return nil, fmt.Errorf("%w: %w", errUpstream, newHTTPStatusError(resp, body))
+			}
+
+			var envelope struct {
+				Data json.RawMessage `json:"data"`
+			}
+			if err := json.Unmarshal(body, &envelope); err != nil {
+				return nil, fmt.Errorf("%w: failed to unmarshal response: %v", errUpstream, err)
+			}
+			return envelope.Data, nil
+		})
+	})
+}
+
+// fetchProjects lists the projects the user behind auth is a member of. Newer auth servers return a page
+// with a projects field, older ones the list itself.
+func (s *LitmusChaosServer) fetchProjects(ctx context.Context, auth *tokenSource) ([]AuthProject, error) {
+	data, err := s.authServerRequest(ctx, auth, "/list_projects")
+	if err != nil {
+		return nil, err
+	}
+
+	var projects []AuthProject
+	if json.Unmarshal(data, &projects) != nil {
+		if err := decodeField(data, "projects", &projects); err != nil {
+			return nil, err
+		}
+	}
+	for i := range projects {
+		if err := projects[i].validate(); err != nil {
+			return nil, fmt.Errorf("%w: projects: %v", errMalformedResponse, err)
+		}
+	}
+	return projects, nil
+}
+
+func (s *LitmusChaosServer) listProjects(ctx context.Context, args map[string]interface{}) (*ToolResult, error) {
+	projects, err := s.fetchProjects(ctx, s.auth)
+	if err != nil {
+		return nil, err
+	}
+	username := s.auth.currentUsername(ctx)
+
+	formattedProjects := []map[string]interface{}{}
+	listed := map[string]bool{}
+	for _, p := range projects {
+		listed[p.ProjectID] = true
+
+		role := ""
+		for _, member := range p.Members {
+			if username != "" && member.Username == username {
+				role = member.Role
+			}
+		}
+
+		formatted := map[string]interface{}{
+			"projectId":   p.ProjectID,
+			"name":        p.Name,
+			"description": p.Description,
+			"tags":        p.Tags,
+			"members":     len(p.Members),
+			"default":     p.ProjectID == s.config.ProjectID,
+		}
+		if role != "" {
+			formatted["role"] = role
+		}
+		if p.State != nil {
+			formatted["state"] = *p.State
+		}
+		if configured := s.configuredProjectName(p.ProjectID); configured != "" {
+			formatted["configuredAs"] = configured
+		}
+		formattedProjects = append(formattedProjects, formatted)
+	}
+
+	// Projects configured with tokens of other users are not visible to the server's own credentials
+	for _, p := range s.projects {
+		if listed[p.ID] {
+			continue
+		}
+		listed[p.ID] = true
+		formattedProjects = append(formattedProjects, map[string]interface{}{
+			"projectId":    p.ID,
+			"configuredAs": p.Name,
+			"default":      p.ID == s.config.ProjectID,
+		})
+	}
+
+	sort.SliceStable(formattedProjects, func(i, j int) bool {
+		return formattedProjects[i]["default"].(bool) && !formattedProjects[j]["default"].(bool)
+	})
+
+	response := map[string]interface{}{
+		"summary":          fmt.Sprintf("Found %d projects", len(formattedProjects)),
+		"defaultProjectId": s.config.ProjectID,
+		"totalProjects":    len(formattedProjects),
+		"projects":         formattedProjects,
+		"usage":            "Pass a projectId or configured project name as the projectId argument of any tool to act on that project",
+	}
+
+	result, _ := json.MarshalIndent(response, "", "  ")
+
+	return &ToolResult{
+		Content: []ContentItem{
+			{
+				Type: "text",
+				Text: string(result),
+			},
+		},
+	}, nil
+}
+
+// configuredProjectName returns the LITMUS_PROJECTS name of a project ID, if it has one.
+func (s *LitmusChaosServer) configuredProjectName(id string) string {
+	for _, p := range s.projects {
+		if p.ID == id {
+			return p.Name
+		}
+	}
+	return ""
+}
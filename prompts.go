@@ -0,0 +1,276 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// MCP prompt types
+type Prompt struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Arguments   []PromptArgument `json:"arguments,omitempty"`
+
+	// Tools whose data the prompt is pre-filled with; the tool policy must allow all of them
+	tools []string
+}
+
+type PromptArgument struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Required    bool   `json:"required"`
+}
+
+type PromptMessage struct {
+	Role    string      `json:"role"`
+	Content ContentItem `json:"content"`
+}
+
+// Prompt definitions
+func (s *LitmusChaosServer) getPrompts() []Prompt {
+	return []Prompt{
+		{
+			Name:        "plan_game_day",
+			Description: "Plan a chaos game day for an environment using its infrastructures, experiments and probes",
+			Arguments: []PromptArgument{
+				{Name: "environmentId", Description: "Environment to plan the game day for", Required: true},
+				{Name: "goal", Description: "What the game day should validate (e.g., failover of the checkout flow)"},
+				{Name: "duration", Description: "Time box for the game day (e.g., 2h)"},
+			},
+			tools: []string{"list_environments", "list_chaos_infrastructures", "list_chaos_experiments", "list_resilience_probes"},
+		},
+		{
+			Name:        "investigate_failed_run",
+			Description: "Investigate why an experiment run failed or scored low, using its execution data and run history",
+			Arguments: []PromptArgument{
+				{Name: "experimentRunId", Description: "Experiment run to investigate", Required: true},
+			},
+			tools: []string{"get_experiment_run_details", "list_experiment_runs"},
+		},
+		{
+			Name:        "harden_service_with_probes",
+			Description: "Propose resilience probes and experiments that harden a service against failure",
+			Arguments: []PromptArgument{
+				{Name: "service", Description: "Service to harden (e.g., payment-service)", Required: true},
+				{Name: "namespace", Description: "Kubernetes namespace the service runs in"},
+				{Name: "endpoint", Description: "Health or business endpoint of the service to probe"},
+			},
+			tools: []string{"list_resilience_probes", "list_chaos_experiments", "list_chaos_hubs", "get_chaos_faults"},
+		},
+	}
+}
+
+func (s *LitmusChaosServer) handleListPrompts() interface{} {
+	prompts := []Prompt{}
+	for _, prompt := range s.getPrompts() {
+		if s.checkPrompt(prompt) == nil {
+			prompts = append(prompts, prompt)
+		}
+	}
+	return map[string]interface{}{
+		"prompts": prompts,
+	}
+}
+
+// checkPrompt returns the tool policy's error for the first tool a prompt is pre-filled with that is
+// not allowed, or nil if the prompt may be used.
+func (s *LitmusChaosServer) checkPrompt(prompt Prompt) error {
+	for _, tool := range prompt.tools {
+		if err := s.policy.decide(tool, lookupTool(tool).mutating); err != nil {
+			return fmt.Errorf("%w (prompt %s)", err, prompt.Name)
+		}
+	}
+	return nil
+}
+
+func (s *LitmusChaosServer) handleGetPrompt(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var getParams struct {
+		Name      string            `json:"name"`
+		Arguments map[string]string `json:"arguments"`
+	}
+
+	if err := json.Unmarshal(params, &getParams); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse get prompt params: %v", errInvalidParams, err)
+	}
+
+	var prompt *Prompt
+	for _, p := range s.getPrompts() {
+		if p.Name == getParams.Name {
+			p := p
+			prompt = &p
+			break
+		}
+	}
+	if prompt == nil {
+		return nil, fmt.Errorf("%w: unknown prompt: %s", errInvalidParams, getParams.Name)
+	}
+	if err := s.checkPrompt(*prompt); err != nil {
+		return nil, err
+	}
+
+	for _, arg := range prompt.Arguments {
+		if arg.Required && getParams.Arguments[arg.Name] == "" {
+			return nil, fmt.Errorf("%w: argument %s is required for prompt %s", errInvalidParams, arg.Name, prompt.Name)
+		}
+	}
+
+	var text string
+	switch prompt.Name {
+	case "plan_game_day":
+		text = s.planGameDayPrompt(ctx, getParams.Arguments)
+	case "investigate_failed_run":
+		text = s.investigateFailedRunPrompt(ctx, getParams.Arguments)
+	case "harden_service_with_probes":
+		text = s.hardenServicePrompt(ctx, getParams.Arguments)
+	}
+
+	return map[string]interface{}{
+		"description": prompt.Description,
+		"messages": []PromptMessage{
+			{
+				Role:    "user",
+				Content: ContentItem{Type: "text", Text: text},
+			},
+		},
+	}, nil
+}
+
+// promptSection renders the output of a tool handler as a titled block of prompt context.
+// Failures are included inline so the prompt is still usable when part of the data is unavailable.
+func promptSection(title string, result *ToolResult, err error) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## %s\n\n", title)
+	if err != nil {
+		fmt.Fprintf(&b, "(unavailable: %v)\n\n", err)
+		return b.String()
+	}
+	b.WriteString("```json\n")
+	for _, item := range result.Content {
+		b.WriteString(item.Text)
+	}
+	b.WriteString("\n```\n\n")
+	return b.String()
+}
+
+// planGameDayPrompt pre-fills the environment, its infrastructures, existing experiments and probes.
+func (s *LitmusChaosServer) planGameDayPrompt(ctx context.Context, args map[string]string) string {
+	environmentID := args["environmentId"]
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Plan a chaos engineering game day for the environment `%s`.\n", environmentID)
+	if goal := args["goal"]; goal != "" {
+		fmt.Fprintf(&b, "The goal of the game day is: %s.\n", goal)
+	}
+	if duration := args["duration"]; duration != "" {
+		fmt.Fprintf(&b, "The game day is time-boxed to %s.\n", duration)
+	}
+	b.WriteString(`
+Using the Chaos Center data below:
+1. Summarize which infrastructures in this environment are active and can run chaos.
+2. Propose an ordered schedule of experiments, reusing existing experiments where they fit and
+   naming new ones (faults, targets, duration) where there are gaps. Start with the smallest blast radius.
+3. For each experiment, state the hypothesis, the resilience probes that validate steady state,
+   and the abort criteria.
+4. List the roles, communication channels and rollback steps the team needs before starting.
+Do not run any experiment until the plan has been reviewed.
+
+`)
+
+	result, err := s.listEnvironments(ctx, map[string]interface{}{})
+	b.WriteString(promptSection("Environments", result, err))
+
+	result, err = s.listChaosInfrastructures(ctx, map[string]interface{}{"environmentId": environmentID})
+	b.WriteString(promptSection(fmt.Sprintf("Chaos infrastructures in %s", environmentID), result, err))
+
+	result, err = s.listChaosExperiments(ctx, map[string]interface{}{
+		"pagination": map[string]interface{}{"page": float64(0), "limit": float64(50)},
+	})
+	b.WriteString(promptSection("Existing chaos experiments", result, err))
+
+	result, err = s.listResilienceProbes(ctx, map[string]interface{}{})
+	b.WriteString(promptSection("Resilience probes", result, err))
+
+	return b.String()
+}
+
+// investigateFailedRunPrompt pre-fills the run's execution data and the recent history of its experiment.
+func (s *LitmusChaosServer) investigateFailedRunPrompt(ctx context.Context, args map[string]string) string {
+	experimentRunID := args["experimentRunId"]
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Investigate the chaos experiment run `%s`.\n", experimentRunID)
+	b.WriteString(`
+Using the run details and history below:
+1. Identify which faults failed or were stopped, and which probes did not meet their criteria.
+2. Explain the most likely root cause of each failure, distinguishing application weaknesses from
+   problems with the experiment setup (targets, permissions, infrastructure health).
+3. Compare with previous runs of the same experiment to tell regressions from long-standing issues;
+   compare_experiment_runs lines up this run with an earlier one fault by fault.
+4. Recommend concrete next steps: fixes to the service, probe tuning, or experiment changes.
+
+`)
+
+	result, err := s.getExperimentRunDetails(ctx, map[string]interface{}{
+		"experimentRunId": experimentRunID,
+		"includeLogs":     true,
+	})
+	b.WriteString(promptSection("Run details", result, err))
+
+	if err == nil {
+		var details map[string]interface{}
+		if json.Unmarshal([]byte(result.Content[0].Text), &details) == nil {
+			if experimentID := getNestedString(details, "run", "experimentId"); experimentID != "" {
+				history, historyErr := s.listExperimentRuns(ctx, map[string]interface{}{
+					"experimentId": experimentID,
+					"limit":        float64(10),
+				})
+				b.WriteString(promptSection("Recent runs of the same experiment", history, historyErr))
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// hardenServicePrompt pre-fills existing probes, experiments and available hub faults.
+func (s *LitmusChaosServer) hardenServicePrompt(ctx context.Context, args map[string]string) string {
+	service := args["service"]
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Help me harden the service `%s`", service)
+	if namespace := args["namespace"]; namespace != "" {
+		fmt.Fprintf(&b, " running in namespace `%s`", namespace)
+	}
+	b.WriteString(" with resilience probes and chaos experiments.\n")
+	if endpoint := args["endpoint"]; endpoint != "" {
+		fmt.Fprintf(&b, "Its health endpoint is %s.\n", endpoint)
+	}
+	b.WriteString(`
+Using the Chaos Center data below:
+1. Define the service's steady state and propose probes (httpProbe, cmdProbe, k8sProbe or promProbe)
+   that measure it, reusing existing probes where possible. Give name, type, mode and criteria for each.
+2. Pick faults from the ChaosHub that exercise the service's likely failure modes
+   (pod loss, network latency, resource pressure, dependency failure).
+3. Describe one experiment per failure mode with targets, duration, fault weights and attached probes,
+   ready to be created with create_chaos_experiment.
+
+`)
+
+	result, err := s.listResilienceProbes(ctx, map[string]interface{}{})
+	b.WriteString(promptSection("Existing resilience probes", result, err))
+
+	result, err = s.listChaosExperiments(ctx, map[string]interface{}{
+		"filter":     map[string]interface{}{"experimentName": service},
+		"pagination": map[string]interface{}{"page": float64(0), "limit": float64(20)},
+	})
+	b.WriteString(promptSection(fmt.Sprintf("Existing experiments matching %s", service), result, err))
+
+	if hubID, hubErr := s.defaultChaosHubID(ctx); hubErr == nil {
+		result, err = s.getChaosFaults(ctx, map[string]interface{}{"hubId": hubID})
+		b.WriteString(promptSection("Available ChaosHub faults", result, err))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,607 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limits and thresholds for get_resilience_trends
+const (
+	runPageSize            = 100
+	maxTrendRuns           = 5000
+	defaultTrendDays       = 30
+	maxTrendDays           = 365
+	defaultRecentDays      = 7
+	dropSignificance       = 0.05
+	minScoreDrop           = 5.0
+	minPassRateDrop        = 10.0
+	minRunsForDropAnalysis = 2
+)
+
+// Run phases left out of trends, since the run did not get to judge the system
+var uncountedRunPhases = map[string]bool{
+	"Stopped": true,
+	"Skipped": true,
+}
+
+// Groupings of get_resilience_trends
+const (
+	trendByExperiment     = "experiment"
+	trendByInfrastructure = "infrastructure"
+	trendByEnvironment    = "environment"
+)
+
+// parseRunTime reads the timestamps of experiment runs, which Chaos Center stores as Unix milliseconds.
+func parseRunTime(value string) (time.Time, bool) {
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if n <= 0 {
+			return time.Time{}, false
+		}
+		// Older runs carry seconds
+		if n < 1e11 {
+			return time.Unix(n, 0).UTC(), true
+		}
+		return time.UnixMilli(n).UTC(), true
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), true
+	}
+	return time.Time{}, false
+}
+
+// getDateFromArgs reads an RFC 3339 timestamp or a YYYY-MM-DD date, returning false if the argument is not set.
+func getDateFromArgs(args map[string]interface{}, key string) (time.Time, bool, error) {
+	value := getStringFromArgs(args, key, "")
+	if value == "" {
+		return time.Time{}, false, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), true, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, true, nil
+	}
+	return time.Time{}, false, fmt.Errorf("%s must be an RFC 3339 timestamp or a date as YYYY-MM-DD, got %q", key, value)
+}
+
+// runDateRange is the dateRange filter of ListExperimentRunRequest, in Unix milliseconds.
+func runDateRange(start, end time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"startDate": strconv.FormatInt(start.UnixMilli(), 10),
+		"endDate":   strconv.FormatInt(end.UnixMilli(), 10),
+	}
+}
+
+// listAllExperimentRuns pages through listExperimentRun with the filters and sort of request, returning at most
+// limit runs and the total number of runs that match.
+func (s *LitmusChaosServer) listAllExperimentRuns(ctx context.Context, request map[string]interface{}, limit int) ([]ExperimentRun, int, error) {
+	var runs []ExperimentRun
+	for page := 0; ; page++ {
+		pageRequest := map[string]interface{}{}
+		for key, value := range request {
+			pageRequest[key] = value
+		}
+		pageRequest["pagination"] = map[string]interface{}{
+			"page":  page,
+			"limit": runPageSize,
+		}
+
+		var listRuns ListExperimentRunResponse
+		if err := s.query(ctx, listExperimentRunsQuery, map[string]interface{}{"request": pageRequest}, "listExperimentRun", &listRuns); err != nil {
+			return nil, 0, fmt.Errorf("failed to list experiment runs: %w", err)
+		}
+		runs = append(runs, listRuns.ExperimentRuns...)
+
+		if len(runs) >= limit {
+			return runs[:limit], listRuns.TotalNoOfExperimentRuns, nil
+		}
+		if len(listRuns.ExperimentRuns) < runPageSize || len(runs) >= listRuns.TotalNoOfExperimentRuns {
+			return runs, listRuns.TotalNoOfExperimentRuns, nil
+		}
+	}
+}
+
+// trendRun is a finished run as counted in trends.
+type trendRun struct {
+	At           time.Time
+	ExperimentID string
+	Score        *float64
+	Passed       bool
+}
+
+// trendStats summarizes a set of runs.
+type trendStats struct {
+	Runs         int      `json:"runs"`
+	AverageScore *float64 `json:"averageScore"`
+	PassRate     *float64 `json:"passRate"`
+}
+
+// trendPoint is the summary of one period of a series.
+type trendPoint struct {
+	Period string `json:"period"`
+	trendStats
+}
+
+// trendDrop is a decline of the recent runs of a group against the earlier runs of the window.
+type trendDrop struct {
+	Metric   string  `json:"metric"`
+	Baseline float64 `json:"baseline"`
+	Recent   float64 `json:"recent"`
+	PValue   float64 `json:"pValue"`
+}
+
+// trendSeries is the trend of one experiment, infrastructure or environment.
+type trendSeries struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	trendStats
+	MTBFHours *float64     `json:"mtbfHours"`
+	Flakiness *float64     `json:"flakiness"`
+	Series    []trendPoint `json:"series"`
+	Drops     []trendDrop  `json:"drops,omitempty"`
+
+	runs []trendRun
+}
+
+func round2(value float64) *float64 {
+	rounded := math.Round(value*100) / 100
+	return &rounded
+}
+
+// summarize computes run count, average score and pass rate. Runs without a score only count towards the pass rate.
+func summarize(runs []trendRun) trendStats {
+	stats := trendStats{Runs: len(runs)}
+	if len(runs) == 0 {
+		return stats
+	}
+
+	var scores []float64
+	passes := 0
+	for _, run := range runs {
+		if run.Score != nil {
+			scores = append(scores, *run.Score)
+		}
+		if run.Passed {
+			passes++
+		}
+	}
+	if len(scores) > 0 {
+		stats.AverageScore = round2(mean(scores))
+	}
+	stats.PassRate = round2(float64(passes) / float64(len(runs)) * 100)
+	return stats
+}
+
+// periodStart returns the start of the day or ISO week containing t.
+func periodStart(t time.Time, bucket string) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if bucket == "week" {
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	}
+	return day
+}
+
+// analyze fills in the statistics, series and drops of the group from its runs, which are sorted by time.
+func (g *trendSeries) analyze(bucket string, recentSince time.Time) {
+	g.trendStats = summarize(g.runs)
+
+	g.Series = []trendPoint{}
+	for i := 0; i < len(g.runs); {
+		start := periodStart(g.runs[i].At, bucket)
+		j := i
+		for j < len(g.runs) && periodStart(g.runs[j].At, bucket).Equal(start) {
+			j++
+		}
+		g.Series = append(g.Series, trendPoint{Period: start.Format("2006-01-02"), trendStats: summarize(g.runs[i:j])})
+		i = j
+	}
+
+	var failures []time.Time
+	for _, run := range g.runs {
+		if !run.Passed {
+			failures = append(failures, run.At)
+		}
+	}
+	if len(failures) >= 2 {
+		g.MTBFHours = round2(failures[len(failures)-1].Sub(failures[0]).Hours() / float64(len(failures)-1))
+	}
+
+	// Flakiness is the share of consecutive runs of the same experiment whose outcome flipped
+	last := map[string]bool{}
+	seen := map[string]bool{}
+	pairs, flips := 0, 0
+	for _, run := range g.runs {
+		if seen[run.ExperimentID] {
+			pairs++
+			if last[run.ExperimentID] != run.Passed {
+				flips++
+			}
+		}
+		seen[run.ExperimentID] = true
+		last[run.ExperimentID] = run.Passed
+	}
+	if pairs > 0 {
+		g.Flakiness = round2(float64(flips) / float64(pairs))
+	}
+
+	var baseline, recent []trendRun
+	for _, run := range g.runs {
+		if run.At.Before(recentSince) {
+			baseline = append(baseline, run)
+		} else {
+			recent = append(recent, run)
+		}
+	}
+	g.Drops = detectDrops(baseline, recent)
+}
+
+// detectDrops compares recent runs with the baseline runs, reporting a drop of the average resiliency score
+// (Welch's t-test) or of the pass rate (two-proportion z-test) that is both large and statistically significant.
+func detectDrops(baseline, recent []trendRun) []trendDrop {
+	var drops []trendDrop
+
+	var baseScores, recentScores []float64
+	for _, run := range baseline {
+		if run.Score != nil {
+			baseScores = append(baseScores, *run.Score)
+		}
+	}
+	for _, run := range recent {
+		if run.Score != nil {
+			recentScores = append(recentScores, *run.Score)
+		}
+	}
+	if len(baseScores) >= minRunsForDropAnalysis && len(recentScores) >= minRunsForDropAnalysis {
+		baseMean, recentMean := mean(baseScores), mean(recentScores)
+		if baseMean-recentMean >= minScoreDrop {
+			if p := welchPValue(baseScores, recentScores); p < dropSignificance {
+				drops = append(drops, trendDrop{Metric: "resiliencyScore", Baseline: *round2(baseMean), Recent: *round2(recentMean), PValue: math.Round(p*1e4) / 1e4})
+			}
+		}
+	}
+
+	if len(baseline) >= minRunsForDropAnalysis && len(recent) >= minRunsForDropAnalysis {
+		basePasses, recentPasses := 0, 0
+		for _, run := range baseline {
+			if run.Passed {
+				basePasses++
+			}
+		}
+		for _, run := range recent {
+			if run.Passed {
+				recentPasses++
+			}
+		}
+		baseRate := float64(basePasses) / float64(len(baseline))
+		recentRate := float64(recentPasses) / float64(len(recent))
+		if (baseRate-recentRate)*100 >= minPassRateDrop {
+			if p := proportionPValue(basePasses, len(baseline), recentPasses, len(recent)); p < dropSignificance {
+				drops = append(drops, trendDrop{Metric: "passRate", Baseline: *round2(baseRate * 100), Recent: *round2(recentRate * 100), PValue: math.Round(p*1e4) / 1e4})
+			}
+		}
+	}
+	return drops
+}
+
+func mean(values []float64) float64 {
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+func variance(values []float64, m float64) float64 {
+	sum := 0.0
+	for _, v := range values {
+		sum += (v - m) * (v - m)
+	}
+	return sum / float64(len(values)-1)
+}
+
+// welchPValue is the one-sided p-value of the recent mean being lower than the baseline mean.
+func welchPValue(baseline, recent []float64) float64 {
+	baseMean, recentMean := mean(baseline), mean(recent)
+	baseVar := variance(baseline, baseMean) / float64(len(baseline))
+	recentVar := variance(recent, recentMean) / float64(len(recent))
+
+	se := math.Sqrt(baseVar + recentVar)
+	if se == 0 {
+		// Both samples are constant, so any difference is certain
+		if recentMean < baseMean {
+			return 0
+		}
+		return 1
+	}
+
+	t := (recentMean - baseMean) / se
+	df := (baseVar + recentVar) * (baseVar + recentVar) /
+		(baseVar*baseVar/float64(len(baseline)-1) + recentVar*recentVar/float64(len(recent)-1))
+	return studentTCDF(t, df)
+}
+
+// proportionPValue is the one-sided p-value of the recent pass rate being lower than the baseline pass rate.
+func proportionPValue(basePasses, baseRuns, recentPasses, recentRuns int) float64 {
+	pooled := float64(basePasses+recentPasses) / float64(baseRuns+recentRuns)
+	se := math.Sqrt(pooled * (1 - pooled) * (1/float64(baseRuns) + 1/float64(recentRuns)))
+	if se == 0 {
+		return 1
+	}
+	z := (float64(recentPasses)/float64(recentRuns) - float64(basePasses)/float64(baseRuns)) / se
+	return 0.5 * math.Erfc(-z/math.Sqrt2)
+}
+
+// studentTCDF returns P(T <= t) for Student's t distribution with df degrees of freedom.
+func studentTCDF(t, df float64) float64 {
+	tail := 0.5 * regularizedIncompleteBeta(df/2, 0.5, df/(df+t*t))
+	if t > 0 {
+		return 1 - tail
+	}
+	return tail
+}
+
+// regularizedIncompleteBeta evaluates I_x(a, b) by its continued fraction.
+func regularizedIncompleteBeta(a, b, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+
+	lgab, _ := math.Lgamma(a + b)
+	lga, _ := math.Lgamma(a)
+	lgb, _ := math.Lgamma(b)
+	front := math.Exp(lgab - lga - lgb + a*math.Log(x) + b*math.Log(1-x))
+	if x < (a+1)/(a+b+2) {
+		return front * betaContinuedFraction(a, b, x) / a
+	}
+	return 1 - front*betaContinuedFraction(b, a, 1-x)/b
+}
+
+func betaContinuedFraction(a, b, x float64) float64 {
+	const (
+		maxIterations = 200
+		epsilon       = 1e-12
+		tiny          = 1e-300
+	)
+
+	c, d := 1.0, 1-(a+b)*x/(a+1)
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	result := d
+
+	for m := 1; m <= maxIterations; m++ {
+		fm := float64(m)
+		for _, numerator := range []float64{
+			fm * (b - fm) * x / ((a + 2*fm - 1) * (a + 2*fm)),
+			-(a + fm) * (a + b + fm) * x / ((a + 2*fm) * (a + 2*fm + 1)),
+		} {
+			d = 1 + numerator*d
+			if math.Abs(d) < tiny {
+				d = tiny
+			}
+			c = 1 + numerator/c
+			if math.Abs(c) < tiny {
+				c = tiny
+			}
+			d = 1 / d
+			result *= d * c
+		}
+		if math.Abs(d*c-1) < epsilon {
+			break
+		}
+	}
+	return result
+}
+
+// getResilienceTrends pages through the experiment runs of a time window and reports resiliency score, pass rate,
+// mean time between failures and flakiness over time per experiment, infrastructure and environment, flagging
+// significant drops of the most recent runs.
+func (s *LitmusChaosServer) getResilienceTrends(ctx context.Context, args map[string]interface{}) (*ToolResult, error) {
+	end, ok, err := getDateFromArgs(args, "endDate")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		end = time.Now().UTC()
+	}
+
+	start, ok, err := getDateFromArgs(args, "startDate")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		days := getIntFromArgs(args, "days", defaultTrendDays)
+		if days < 1 || days > maxTrendDays {
+			return nil, fmt.Errorf("days must be between 1 and %d", maxTrendDays)
+		}
+		start = end.AddDate(0, 0, -days)
+	}
+	if !start.Before(end) {
+		return nil, fmt.Errorf("startDate must be before endDate")
+	}
+
+	recentDays := getIntFromArgs(args, "recentDays", defaultRecentDays)
+	if recentDays < 1 {
+		return nil, fmt.Errorf("recentDays must be at least 1")
+	}
+	recentSince := end.AddDate(0, 0, -recentDays)
+	if !recentSince.After(start) {
+		return nil, fmt.Errorf("recentDays must be shorter than the window, which starts %s", start.Format(time.RFC3339))
+	}
+
+	bucket := getStringFromArgs(args, "bucket", "")
+	switch bucket {
+	case "":
+		bucket = "day"
+		if end.Sub(start) > 31*24*time.Hour {
+			bucket = "week"
+		}
+	case "day", "week":
+	default:
+		return nil, fmt.Errorf("bucket must be day or week, got %q", bucket)
+	}
+
+	groupBy := map[string]bool{trendByExperiment: true, trendByInfrastructure: true, trendByEnvironment: true}
+	if values := stringList(args, "groupBy"); len(values) > 0 {
+		groupBy = map[string]bool{}
+		for _, value := range values {
+			if value != trendByExperiment && value != trendByInfrastructure && value != trendByEnvironment {
+				return nil, fmt.Errorf("groupBy must list %s, %s or %s, got %q", trendByExperiment, trendByInfrastructure, trendByEnvironment, value)
+			}
+			groupBy[value] = true
+		}
+	}
+
+	filter := map[string]interface{}{
+		"dateRange": runDateRange(start, end),
+	}
+	if infraID := getStringFromArgs(args, "infraId", ""); infraID != "" {
+		filter["infraID"] = infraID
+	}
+	request := map[string]interface{}{
+		"filter": filter,
+		"sort":   map[string]interface{}{"field": "TIME", "ascending": false},
+	}
+	if experimentID := getStringFromArgs(args, "experimentId", ""); experimentID != "" {
+		request["experimentIDs"] = []string{experimentID}
+	}
+
+	runs, total, err := s.listAllExperimentRuns(ctx, request, maxTrendRuns)
+	if err != nil {
+		return nil, err
+	}
+
+	environmentNames := map[string]string{}
+	if groupBy[trendByEnvironment] {
+		var listEnvs ListEnvironmentResponse
+		if err := s.query(ctx, listEnvironmentsQuery, map[string]interface{}{}, "listEnvironments", &listEnvs); err == nil {
+			for _, env := range listEnvs.Environments {
+				environmentNames[env.EnvironmentID] = env.Name
+			}
+		}
+	}
+	environmentFilter := getStringFromArgs(args, "environmentId", "")
+
+	overall := &trendSeries{ID: "all", Name: "All runs"}
+	groups := map[string]map[string]*trendSeries{
+		trendByExperiment:     {},
+		trendByInfrastructure: {},
+		trendByEnvironment:    {},
+	}
+	addTo := func(kind, id, name string, run trendRun) {
+		if !groupBy[kind] || id == "" {
+			return
+		}
+		group, ok := groups[kind][id]
+		if !ok {
+			if name == "" {
+				name = id
+			}
+			group = &trendSeries{ID: id, Name: name}
+			groups[kind][id] = group
+		}
+		group.runs = append(group.runs, run)
+	}
+
+	sort.SliceStable(runs, func(i, j int) bool {
+		a, _ := parseRunTime(runs[i].CreatedAt)
+		b, _ := parseRunTime(runs[j].CreatedAt)
+		return a.Before(b)
+	})
+
+	skipped := 0
+	for _, run := range runs {
+		at, ok := parseRunTime(run.CreatedAt)
+		if !ok || at.Before(start) || at.After(end) {
+			continue
+		}
+		if !terminalRunPhases[run.Phase] || uncountedRunPhases[run.Phase] {
+			skipped++
+			continue
+		}
+
+		var infraID, infraName, environmentID string
+		if run.Infra != nil {
+			infraID, infraName, environmentID = run.Infra.InfraID, run.Infra.Name, run.Infra.EnvironmentID
+		}
+		if environmentFilter != "" && environmentID != environmentFilter {
+			continue
+		}
+
+		trend := trendRun{At: at, ExperimentID: run.ExperimentID, Score: run.ResiliencyScore, Passed: run.Phase == "Completed"}
+		overall.runs = append(overall.runs, trend)
+		addTo(trendByExperiment, run.ExperimentID, run.ExperimentName, trend)
+		addTo(trendByInfrastructure, infraID, infraName, trend)
+		addTo(trendByEnvironment, environmentID, environmentNames[environmentID], trend)
+	}
+
+	overall.analyze(bucket, recentSince)
+	drops := []string{}
+	describeDrops := func(kind string, group *trendSeries) {
+		for _, drop := range group.Drops {
+			drops = append(drops, fmt.Sprintf("%s %s: %s fell from %g to %g (p=%g)", kind, group.Name, drop.Metric, drop.Baseline, drop.Recent, drop.PValue))
+		}
+	}
+	describeDrops("project", overall)
+
+	response := map[string]interface{}{
+		"window": map[string]interface{}{
+			"start":       start.Format(time.RFC3339),
+			"end":         end.Format(time.RFC3339),
+			"bucket":      bucket,
+			"recentSince": recentSince.Format(time.RFC3339),
+		},
+		"runsAnalyzed": overall.Runs,
+		"overall":      overall,
+	}
+
+	for _, kind := range []string{trendByExperiment, trendByInfrastructure, trendByEnvironment} {
+		if !groupBy[kind] {
+			continue
+		}
+		series := []*trendSeries{}
+		for _, group := range groups[kind] {
+			group.analyze(bucket, recentSince)
+			series = append(series, group)
+		}
+		sort.Slice(series, func(i, j int) bool {
+			return strings.ToLower(series[i].Name) < strings.ToLower(series[j].Name)
+		})
+		for _, group := range series {
+			describeDrops(kind, group)
+		}
+		response[kind+"s"] = series
+	}
+
+	response["drops"] = drops
+	response["summary"] = fmt.Sprintf("Analyzed %d finished runs between %s and %s; %d significant drops", overall.Runs, start.Format("2006-01-02"), end.Format("2006-01-02"), len(drops))
+	if skipped > 0 {
+		response["runsSkipped"] = skipped
+	}
+	if len(runs) < total {
+		response["truncated"] = true
+		response["message"] = fmt.Sprintf("Only the latest %d of %d runs in the window were analyzed; narrow the window or filter by experiment or infrastructure", len(runs), total)
+	}
+
+	responseJSON, _ := json.MarshalIndent(response, "", "  ")
+
+	return &ToolResult{
+		Content: []ContentItem{
+			{
+				Type: "text",
+				Text: string(responseJSON),
+			},
+		},
+	}, nil
+}
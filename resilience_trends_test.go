@@ -0,0 +1,198 @@
+package main
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestRegularizedIncompleteBeta(t *testing.T) {
+	tests := []struct {
+		a, b, x float64
+		want    float64
+	}{
+		{a: 1, b: 1, x: 0.3, want: 0.3},
+		{a: 2, b: 1, x: 0.3, want: 0.09},
+		{a: 1, b: 3, x: 0.3, want: 0.657},
+		{a: 2, b: 2, x: 0.2, want: 0.104},
+		{a: 3, b: 3, x: 0.5, want: 0.5},
+		{a: 2.5, b: 0.5, x: 0, want: 0},
+		{a: 2.5, b: 0.5, x: 1, want: 1},
+	}
+
+	for _, tt := range tests {
+		if got := regularizedIncompleteBeta(tt.a, tt.b, tt.x); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("I_%v(%v, %v) = %v, want %v", tt.x, tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestStudentTCDF(t *testing.T) {
+	tests := []struct {
+		t, df float64
+		want  float64
+	}{
+		{t: 0, df: 7, want: 0.5},
+		{t: 1, df: 1, want: 0.75},
+		{t: -1, df: 1, want: 0.25},
+		{t: 1, df: 2, want: 0.5 + 1/(2*math.Sqrt(3))},
+		// Critical values from t tables
+		{t: -2.228138852, df: 10, want: 0.025},
+		{t: 2.228138852, df: 10, want: 0.975},
+		{t: -2.015048373, df: 5, want: 0.05},
+		{t: -1.697260887, df: 30, want: 0.05},
+		// Welch's degrees of freedom are not whole numbers
+		{t: -4.201805852, df: 5.248049922, want: 0.003811902},
+		// Large samples approach the normal distribution
+		{t: -1.959963985, df: 1e6, want: 0.025},
+	}
+
+	for _, tt := range tests {
+		if got := studentTCDF(tt.t, tt.df); math.Abs(got-tt.want) > 1e-6 {
+			t.Errorf("studentTCDF(%v, %v) = %v, want %v", tt.t, tt.df, got, tt.want)
+		}
+	}
+}
+
+func TestWelchPValue(t *testing.T) {
+	tests := []struct {
+		name             string
+		baseline, recent []float64
+		want             float64
+	}{
+		{name: "clear drop", baseline: []float64{80, 85, 90, 95, 100}, recent: []float64{70, 72, 74, 76, 78}, want: 0.003811902},
+		{name: "noisy drop", baseline: []float64{80, 90}, recent: []float64{70, 85}, want: 0.251918932},
+		{name: "no change", baseline: []float64{60, 70, 80}, recent: []float64{62, 71, 79}, want: 0.532880148},
+		{name: "constant samples, lower", baseline: []float64{100, 100, 100}, recent: []float64{80, 80}, want: 0},
+		{name: "constant samples, equal", baseline: []float64{100, 100}, recent: []float64{100, 100}, want: 1},
+		{name: "constant samples, higher", baseline: []float64{80, 80}, recent: []float64{100, 100}, want: 1},
+	}
+
+	for _, tt := range tests {
+		if got := welchPValue(tt.baseline, tt.recent); math.Abs(got-tt.want) > 1e-6 {
+			t.Errorf("%s: welchPValue = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestProportionPValue(t *testing.T) {
+	tests := []struct {
+		name                                           string
+		basePasses, baseRuns, recentPasses, recentRuns int
+		want                                           float64
+	}{
+		// z = -2.7603
+		{name: "drop", basePasses: 18, baseRuns: 20, recentPasses: 10, recentRuns: 20, want: 0.002887749},
+		{name: "same rate", basePasses: 5, baseRuns: 10, recentPasses: 10, recentRuns: 20, want: 0.5},
+		{name: "all passed", basePasses: 10, baseRuns: 10, recentPasses: 4, recentRuns: 4, want: 1},
+		{name: "all failed", basePasses: 0, baseRuns: 10, recentPasses: 0, recentRuns: 4, want: 1},
+	}
+
+	for _, tt := range tests {
+		if got := proportionPValue(tt.basePasses, tt.baseRuns, tt.recentPasses, tt.recentRuns); math.Abs(got-tt.want) > 1e-6 {
+			t.Errorf("%s: proportionPValue = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// scoredRuns returns one run per score, passed when the score is at least 50. A negative score means no score.
+func scoredRuns(scores ...float64) []trendRun {
+	runs := make([]trendRun, 0, len(scores))
+	for _, score := range scores {
+		run := trendRun{Passed: score >= 50}
+		if score >= 0 {
+			score := score
+			run.Score = &score
+		}
+		runs = append(runs, run)
+	}
+	return runs
+}
+
+// passedRuns returns the given number of runs without a score, of which the first passes passed.
+func passedRuns(passes, runs int) []trendRun {
+	result := make([]trendRun, runs)
+	for i := 0; i < passes; i++ {
+		result[i].Passed = true
+	}
+	return result
+}
+
+func TestDetectDrops(t *testing.T) {
+	tests := []struct {
+		name             string
+		baseline, recent []trendRun
+		want             []trendDrop
+	}{
+		{
+			name:     "significant score drop",
+			baseline: scoredRuns(80, 85, 90, 95, 100),
+			recent:   scoredRuns(70, 72, 74, 76, 78),
+			want:     []trendDrop{{Metric: "resiliencyScore", Baseline: 90, Recent: 74, PValue: 0.0038}},
+		},
+		{
+			name:     "constant scores",
+			baseline: scoredRuns(100, 100, 100),
+			recent:   scoredRuns(80, 80),
+			want:     []trendDrop{{Metric: "resiliencyScore", Baseline: 100, Recent: 80, PValue: 0}},
+		},
+		{
+			name:     "unchanged constant scores",
+			baseline: scoredRuns(100, 100, 100),
+			recent:   scoredRuns(100, 100),
+		},
+		{
+			name:     "drop below the minimum size",
+			baseline: scoredRuns(90, 90, 90, 90),
+			recent:   scoredRuns(86, 86, 86, 86),
+		},
+		{
+			name:     "drop that is not significant",
+			baseline: scoredRuns(80, 90),
+			recent:   scoredRuns(70, 85),
+		},
+		{
+			name:     "too few recent runs",
+			baseline: scoredRuns(100, 100, 100, 100),
+			recent:   scoredRuns(0),
+		},
+		{
+			name:     "too few baseline runs",
+			baseline: scoredRuns(100),
+			recent:   scoredRuns(0, 0, 0, 0),
+		},
+		{
+			name:     "runs without a score only count for the pass rate",
+			baseline: scoredRuns(100, 100, -1),
+			recent:   scoredRuns(-1, -1, 0),
+			// z = -1.7321
+			want: []trendDrop{{Metric: "passRate", Baseline: 66.67, Recent: 0, PValue: 0.0416}},
+		},
+		{
+			name:     "significant pass rate drop",
+			baseline: passedRuns(18, 20),
+			recent:   passedRuns(10, 20),
+			want:     []trendDrop{{Metric: "passRate", Baseline: 90, Recent: 50, PValue: 0.0029}},
+		},
+		{
+			name:     "pass rate drop on the minimum run counts",
+			baseline: passedRuns(2, 2),
+			recent:   passedRuns(0, 2),
+			// z = -2
+			want: []trendDrop{{Metric: "passRate", Baseline: 100, Recent: 0, PValue: 0.0228}},
+		},
+		{
+			name:     "pass rate improves",
+			baseline: passedRuns(10, 20),
+			recent:   passedRuns(18, 20),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectDrops(tt.baseline, tt.recent); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("detectDrops = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,324 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MCP resource types
+type Resource struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+}
+
+type ResourceTemplate struct {
+	URITemplate string `json:"uriTemplate"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+}
+
+type ResourceContents struct {
+	URI      string `json:"uri"`
+	MimeType string `json:"mimeType,omitempty"`
+	Text     string `json:"text"`
+}
+
+const litmusURIScheme = "litmus://"
+
+// Number of recent runs advertised by resources/list
+const recentRunResourceLimit = 20
+
+// errResourceNotFound is returned by resources/read for URIs that do not name a known resource.
+var errResourceNotFound = errors.New("resource not found")
+
+// getResourceTemplates describes the parameterized litmus:// URIs understood by resources/read.
+func (s *LitmusChaosServer) getResourceTemplates() []ResourceTemplate {
+	return []ResourceTemplate{
+		{
+			URITemplate: "litmus://experiments/{experimentId}",
+			Name:        "Chaos experiment",
+			Description: "Experiment details including its workflow manifest, faults, weightages and infrastructure",
+			MimeType:    "application/json",
+		},
+		{
+			URITemplate: "litmus://runs/{experimentRunId}/manifest",
+			Name:        "Experiment run manifest",
+			Description: "Workflow manifest that was executed for an experiment run",
+			MimeType:    "application/json",
+		},
+		{
+			URITemplate: "litmus://infras/{infraId}/manifest",
+			Name:        "Chaos infrastructure install manifest",
+			Description: "Kubernetes YAML that installs a chaos infrastructure",
+			MimeType:    "application/yaml",
+		},
+		{
+			URITemplate: "litmus://hubs/{hubId}/faults/{category}/{fault}",
+			Name:        "ChaosHub fault definition",
+			Description: "ChaosExperiment and sample ChaosEngine YAML of a ChaosHub fault",
+			MimeType:    "application/yaml",
+		},
+	}
+}
+
+func (s *LitmusChaosServer) handleListResourceTemplates() interface{} {
+	templates := []ResourceTemplate{}
+	for _, template := range s.getResourceTemplates() {
+		if s.resourceListed(template.URITemplate) {
+			templates = append(templates, template)
+		}
+	}
+	return map[string]interface{}{
+		"resourceTemplates": templates,
+	}
+}
+
+// resourceTool names the tool that returns the same data as a litmus:// URI or URI template,
+// so that the tool policy applies to resources as well.
+func resourceTool(uri string) string {
+	switch uri {
+	case experimentRunEventsURI:
+		return "list_experiment_runs"
+	case infraEventsURI:
+		return "list_chaos_infrastructures"
+	}
+
+	kind, _, _ := strings.Cut(strings.TrimPrefix(uri, litmusURIScheme), "/")
+	switch kind {
+	case "experiments":
+		return "get_chaos_experiment"
+	case "runs":
+		return "get_experiment_run_details"
+	case "infras":
+		return "get_infrastructure_details"
+	case "hubs":
+		return "get_chaos_faults"
+	}
+	return ""
+}
+
+// resourceListed reports whether the tool policy lets clients see a resource.
+func (s *LitmusChaosServer) resourceListed(uri string) bool {
+	tool := resourceTool(uri)
+	return tool != "" && s.policy.listed(tool)
+}
+
+// checkResource returns the tool policy's error for reading a resource, or nil if it may be read.
+func (s *LitmusChaosServer) checkResource(uri string) error {
+	tool := resourceTool(uri)
+	if tool == "" {
+		return fmt.Errorf("%w: %s", errResourceNotFound, uri)
+	}
+	if err := s.policy.check(tool, nil); err != nil {
+		return fmt.Errorf("%w (resource %s)", err, uri)
+	}
+	return nil
+}
+
+// handleListResources advertises the project's experiments, infrastructure manifests and recent run manifests.
+func (s *LitmusChaosServer) handleListResources(ctx context.Context) (interface{}, error) {
+	query := `
+		query ListResources($projectID: ID!, $experimentRequest: ListExperimentRequest!, $runRequest: ListExperimentRunRequest!) {
+			listExperiment(projectID: $projectID, request: $experimentRequest) {
+				experiments {
+					experimentID
+					name
+					description
+				}
+			}
+			listExperimentRun(projectID: $projectID, request: $runRequest) {
+				experimentRuns {
+					experimentRunID
+					experimentID
+					experimentName
+					phase
+					updatedAt
+				}
+			}
+			listInfras(projectID: $projectID) {
+				infras {
+					infraID
+					name
+					description
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"experimentRequest": map[string]interface{}{
+			"pagination": map[string]interface{}{"page": 0, "limit": 100},
+		},
+		"runRequest": map[string]interface{}{
+			"pagination": map[string]interface{}{"page": 0, "limit": recentRunResourceLimit},
+		},
+	}
+
+	data, err := s.graphqlRequest(ctx, query, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	var experiments ListExperimentResponse
+	var runs ListExperimentRunResponse
+	var infras ListInfraResponse
+	if err := decodeField(data, "listExperiment", &experiments); err != nil {
+		return nil, err
+	}
+	if err := decodeField(data, "listExperimentRun", &runs); err != nil {
+		return nil, err
+	}
+	if err := decodeField(data, "listInfras", &infras); err != nil {
+		return nil, err
+	}
+
+	resources := []Resource{}
+
+	for _, exp := range experiments.Experiments {
+		resources = append(resources, Resource{
+			URI:         litmusURIScheme + "experiments/" + exp.ExperimentID,
+			Name:        exp.Name,
+			Description: exp.Description,
+			MimeType:    "application/json",
+		})
+	}
+
+	for _, infra := range infras.Infras {
+		resources = append(resources, Resource{
+			URI:         litmusURIScheme + "infras/" + infra.InfraID + "/manifest",
+			Name:        fmt.Sprintf("%s install manifest", infra.Name),
+			Description: infra.Description,
+			MimeType:    "application/yaml",
+		})
+	}
+
+	for _, run := range runs.ExperimentRuns {
+		resources = append(resources, Resource{
+			URI:         litmusURIScheme + "runs/" + run.ExperimentRunID + "/manifest",
+			Name:        fmt.Sprintf("%s run manifest", run.ExperimentName),
+			Description: fmt.Sprintf("Run %s, last updated %s", run.Phase, run.UpdatedAt),
+			MimeType:    "application/json",
+		})
+	}
+
+	resources = append(resources,
+		Resource{
+			URI:         experimentRunEventsURI,
+			Name:        "Live experiment run events",
+			Description: "Most recent experiment run events; subscribe to be notified as runs progress",
+			MimeType:    "application/json",
+		},
+		Resource{
+			URI:         infraEventsURI,
+			Name:        "Live infrastructure events",
+			Description: "Most recent infrastructure connection events; subscribe to be notified as infrastructures connect or disconnect",
+			MimeType:    "application/json",
+		},
+	)
+
+	visible := make([]Resource, 0, len(resources))
+	for _, resource := range resources {
+		if s.resourceListed(resource.URI) {
+			visible = append(visible, resource)
+		}
+	}
+
+	return map[string]interface{}{
+		"resources": visible,
+	}, nil
+}
+
+// handleReadResource resolves a litmus:// URI to its contents.
+func (s *LitmusChaosServer) handleReadResource(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var readParams struct {
+		URI string `json:"uri"`
+	}
+
+	if err := json.Unmarshal(params, &readParams); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse read resource params: %v", errInvalidParams, err)
+	}
+
+	contents, err := s.readResource(ctx, readParams.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"contents": []ResourceContents{*contents},
+	}, nil
+}
+
+func (s *LitmusChaosServer) readResource(ctx context.Context, uri string) (*ResourceContents, error) {
+	if !strings.HasPrefix(uri, litmusURIScheme) {
+		return nil, fmt.Errorf("%w: %s", errResourceNotFound, uri)
+	}
+
+	parts := strings.Split(strings.TrimPrefix(uri, litmusURIScheme), "/")
+	for _, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("%w: %s", errResourceNotFound, uri)
+		}
+	}
+	if err := s.checkResource(uri); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case uri == experimentRunEventsURI || uri == infraEventsURI:
+		s.events.start(s.projectFromContext(ctx))
+		text, _ := json.MarshalIndent(map[string]interface{}{
+			"events": s.events.snapshot(uri),
+		}, "", "  ")
+		return &ResourceContents{URI: uri, MimeType: "application/json", Text: string(text)}, nil
+
+	case len(parts) == 2 && parts[0] == "experiments":
+		getExperiment, err := s.fetchExperiment(ctx, parts[1])
+		if err != nil {
+			return nil, err
+		}
+		text, _ := json.MarshalIndent(formatExperimentDetails(getExperiment), "", "  ")
+		return &ResourceContents{URI: uri, MimeType: "application/json", Text: string(text)}, nil
+
+	case len(parts) == 3 && parts[0] == "runs" && parts[2] == "manifest":
+		run, err := s.fetchExperimentRun(ctx, parts[1], "")
+		if err != nil {
+			return nil, err
+		}
+		manifest := run.ExperimentManifest
+		if manifest == "" {
+			return nil, fmt.Errorf("%w: run %s has no manifest", errResourceNotFound, parts[1])
+		}
+		var indented bytes.Buffer
+		if json.Indent(&indented, []byte(manifest), "", "  ") == nil {
+			manifest = indented.String()
+		}
+		return &ResourceContents{URI: uri, MimeType: "application/json", Text: manifest}, nil
+
+	case len(parts) == 3 && parts[0] == "infras" && parts[2] == "manifest":
+		manifest, err := s.fetchInfraManifest(ctx, parts[1])
+		if err != nil {
+			return nil, err
+		}
+		return &ResourceContents{URI: uri, MimeType: "application/yaml", Text: manifest}, nil
+
+	case len(parts) == 5 && parts[0] == "hubs" && parts[2] == "faults":
+		def, err := s.fetchHubFault(ctx, parts[1], parts[3], parts[4])
+		if err != nil {
+			return nil, err
+		}
+		text := strings.TrimRight(def.Fault, "\n")
+		if def.Engine != "" {
+			text += "\n---\n" + strings.TrimRight(def.Engine, "\n")
+		}
+		return &ResourceContents{URI: uri, MimeType: "application/yaml", Text: text + "\n"}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", errResourceNotFound, uri)
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults for requests to Chaos Center
+const (
+	defaultRequestTimeout   = 30 * time.Second
+	defaultMaxRetries       = 3
+	defaultRetryBaseDelay   = 500 * time.Millisecond
+	defaultRetryMaxDelay    = 10 * time.Second
+	defaultBreakerThreshold = 5
+	defaultBreakerCooldown  = 30 * time.Second
+	maxErrorBodyLength      = 200
+)
+
+// errCircuitOpen is returned without contacting Chaos Center while the circuit breaker is open.
+var errCircuitOpen = errors.New("circuit breaker is open")
+
+// httpStatusError is a non-2xx response from Chaos Center that did not carry GraphQL errors.
+type httpStatusError struct {
+	StatusCode int
+	Status     string
+	Body       string
+	RetryAfter time.Duration
+}
+
+func (e *httpStatusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("Chaos Center returned %s", e.Status)
+	}
+	return fmt.Sprintf("Chaos Center returned %s: %s", e.Status, e.Body)
+}
+
+// temporary reports whether the status indicates Chaos Center may succeed if asked again.
+func (e *httpStatusError) temporary() bool {
+	switch e.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// newHTTPStatusError captures a failed response, keeping a short excerpt of its body for the error message.
+func newHTTPStatusError(resp *http.Response, body []byte) *httpStatusError {
+	excerpt := strings.TrimSpace(string(body))
+	if len(excerpt) > maxErrorBodyLength {
+		excerpt = excerpt[:maxErrorBodyLength] + "..."
+	}
+
+	statusErr := &httpStatusError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Body:       excerpt,
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		statusErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+	return statusErr
+}
+
+// isUnavailable reports whether err means Chaos Center could not be reached or could not serve the request,
+// as opposed to answering with a GraphQL or authentication error.
+func isUnavailable(err error) bool {
+	var statusErr *httpStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.temporary()
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// isMutation reports whether a GraphQL document is a mutation, which must not be sent twice.
+func isMutation(query string) bool {
+	return strings.HasPrefix(strings.TrimSpace(query), "mutation")
+}
+
+// circuitBreaker stops sending requests to Chaos Center after repeated failures,
+// letting a single trial request through once the cooldown has passed.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	open     bool
+	trial    bool
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown}
+}
+
+// allow returns errCircuitOpen if the request must fail fast.
+func (b *circuitBreaker) allow() error {
+	if b.threshold <= 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.open {
+		return nil
+	}
+	if wait := b.cooldown - time.Since(b.openedAt); wait > 0 || b.trial {
+		if wait < 0 {
+			wait = 0
+		}
+		return fmt.Errorf("%w: %w after %d consecutive failures, next attempt in %s",
+			errUpstream, errCircuitOpen, b.failures, wait.Round(100*time.Millisecond))
+	}
+
+	// Half-open: let one request find out whether Chaos Center is back
+	b.trial = true
+	return nil
+}
+
+// record updates the breaker with the outcome of a request that was allowed through.
+func (b *circuitBreaker) record(failed bool) {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+	if !failed {
+		b.failures = 0
+		b.open = false
+		return
+	}
+
+	b.failures++
+	if b.open || b.failures >= b.threshold {
+		if !b.open {
+			log.Printf("Chaos Center failed %d consecutive requests, opening circuit breaker for %s", b.failures, b.cooldown)
+		}
+		b.open = true
+		b.openedAt = time.Now()
+	}
+}
+
+// release ends a half-open trial whose outcome is unknown because the caller gave up on it.
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	b.trial = false
+	b.mu.Unlock()
+}
+
+// retryDelay returns the jittered exponential backoff before the given retry, honouring Retry-After.
+func (s *LitmusChaosServer) retryDelay(retry int, err error) time.Duration {
+	backoff := s.config.RetryBaseDelay << (retry - 1)
+	if backoff <= 0 || backoff > s.config.RetryMaxDelay {
+		backoff = s.config.RetryMaxDelay
+	}
+	delay := backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+
+	var statusErr *httpStatusError
+	if errors.As(err, &statusErr) && statusErr.RetryAfter > delay {
+		delay = statusErr.RetryAfter
+	}
+	return delay
+}
+
+// withRetry runs attempt through the circuit breaker. When idempotent is set, failures caused by Chaos Center being
+// unavailable are retried up to the configured limit. The returned error reports how many attempts were made.
+func (s *LitmusChaosServer) withRetry(ctx context.Context, idempotent bool, attempt func() (json.RawMessage, error)) (json.RawMessage, error) {
+	start := time.Now()
+
+	retries := 0
+	if idempotent {
+		retries = s.config.MaxRetries
+	}
+
+	var lastErr error
+	for n := 1; ; n++ {
+		if err := s.breaker.allow(); err != nil {
+			if lastErr != nil {
+				return nil, fmt.Errorf("%w (stopped after %d attempts, last error: %v)", err, n-1, lastErr)
+			}
+			return nil, err
+		}
+
+		data, err := attempt()
+		if ctx.Err() != nil {
+			s.breaker.release()
+		} else {
+			s.breaker.record(err != nil && isUnavailable(err))
+		}
+		if err == nil {
+			return data, nil
+		}
+
+		if !isUnavailable(err) || ctx.Err() != nil {
+			return nil, err
+		}
+		if !idempotent {
+			return nil, fmt.Errorf("%w (not retried: mutations are sent at most once)", err)
+		}
+		if n > retries {
+			if n == 1 {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%w (gave up after %d attempts in %s)", err, n, time.Since(start).Round(time.Millisecond))
+		}
+
+		lastErr = err
+		delay := s.retryDelay(n, err)
+		log.Printf("Chaos Center request failed (attempt %d of %d), retrying in %s: %v", n, retries+1, delay.Round(time.Millisecond), err)
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w (cancelled after %d attempts: %w)", err, n, ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Duration changes smaller than this share of the baseline duration, or than minDurationChangeSeconds, are not reported
+const (
+	durationChangeRatio      = 0.5
+	minDurationChangeSeconds = 30
+)
+
+// faultOutcome is what happened to one fault in one run.
+type faultOutcome struct {
+	Step                   string            `json:"step"`
+	Phase                  string            `json:"phase"`
+	Verdict                string            `json:"verdict"`
+	FailStep               string            `json:"failStep,omitempty"`
+	ProbeSuccessPercentage *float64          `json:"probeSuccessPercentage"`
+	DurationSeconds        *float64          `json:"durationSeconds"`
+	Weight                 int               `json:"weight"`
+	ScoreContribution      *float64          `json:"scoreContribution"`
+	Probes                 map[string]string `json:"probes,omitempty"`
+}
+
+// faultComparison lines up a fault of the baseline run with the same fault of the compared run.
+type faultComparison struct {
+	Fault      string        `json:"fault"`
+	Baseline   *faultOutcome `json:"baseline"`
+	Comparison *faultOutcome `json:"comparison"`
+	Changes    []string      `json:"changes,omitempty"`
+	Regression bool          `json:"regression"`
+}
+
+// faultOutcomes reads the outcome of every fault of a run, keyed by fault name. A fault that runs more than once is
+// keyed as name#2, name#3 and so on, in the order the steps started. Score contributions follow Chaos Center's
+// resiliency score: the weighted probe success percentages divided by the total weight of the run's faults.
+func faultOutcomes(execution *executionData, weights map[string]int) ([]string, map[string]*faultOutcome) {
+	var keys []string
+	outcomes := map[string]*faultOutcome{}
+	occurrences := map[string]int{}
+	totalWeight := 0
+
+	for _, node := range execution.faultNodes() {
+		data := node.ChaosData
+
+		key := data.ExperimentName
+		occurrences[key]++
+		if occurrences[key] > 1 {
+			key = fmt.Sprintf("%s#%d", key, occurrences[key])
+		}
+
+		weight, ok := weights[data.ExperimentName]
+		if !ok {
+			weight = defaultFaultWeight
+		}
+		totalWeight += weight
+
+		outcome := &faultOutcome{
+			Step:     node.Name,
+			Phase:    node.Phase,
+			Verdict:  data.ExperimentVerdict,
+			FailStep: data.FailStep,
+			Weight:   weight,
+		}
+		if percentage, ok := data.probeSuccessPercentage(); ok {
+			outcome.ProbeSuccessPercentage = &percentage
+		}
+		if duration, ok := node.duration(); ok {
+			seconds := duration.Seconds()
+			outcome.DurationSeconds = &seconds
+		}
+		for _, probe := range data.probeStatuses() {
+			if outcome.Probes == nil {
+				outcome.Probes = map[string]string{}
+			}
+			outcome.Probes[probe.Name] = probe.Status.Verdict
+		}
+
+		keys = append(keys, key)
+		outcomes[key] = outcome
+	}
+
+	for _, outcome := range outcomes {
+		if outcome.ProbeSuccessPercentage != nil && totalWeight > 0 {
+			contribution := math.Round(float64(outcome.Weight)**outcome.ProbeSuccessPercentage/float64(totalWeight)*100) / 100
+			outcome.ScoreContribution = &contribution
+		}
+	}
+	return keys, outcomes
+}
+
+// passed reports whether a verdict or probe status is a pass, which older subscribers decorate, as in "Passed 👍".
+func passed(verdict string) bool {
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(verdict)), "pass")
+}
+
+// compare fills in the changes from the baseline to the compared run, marking the comparison as a regression if the
+// fault stopped passing, or its probes or score contribution went down.
+func (c *faultComparison) compare() {
+	base, next := c.Baseline, c.Comparison
+	switch {
+	case base == nil:
+		c.Changes = append(c.Changes, "fault was added")
+		return
+	case next == nil:
+		c.Changes = append(c.Changes, "fault was removed")
+		return
+	}
+
+	if base.Verdict != next.Verdict {
+		c.Changes = append(c.Changes, fmt.Sprintf("verdict %s → %s", orNone(base.Verdict), orNone(next.Verdict)))
+		c.Regression = c.Regression || (passed(base.Verdict) && !passed(next.Verdict))
+	}
+	if base.FailStep != next.FailStep && next.FailStep != "" {
+		c.Changes = append(c.Changes, fmt.Sprintf("failed at %s", next.FailStep))
+	}
+
+	if changed, down := comparePercentages(base.ProbeSuccessPercentage, next.ProbeSuccessPercentage); changed != "" {
+		c.Changes = append(c.Changes, "probe success "+changed)
+		c.Regression = c.Regression || down
+	}
+
+	for _, name := range sortedKeys(base.Probes) {
+		verdict := base.Probes[name]
+		switch nextVerdict, ok := next.Probes[name]; {
+		case !ok:
+			c.Changes = append(c.Changes, fmt.Sprintf("probe %s was removed", name))
+		case verdict != nextVerdict:
+			c.Changes = append(c.Changes, fmt.Sprintf("probe %s %s → %s", name, orNone(verdict), orNone(nextVerdict)))
+			c.Regression = c.Regression || (passed(verdict) && !passed(nextVerdict))
+		}
+	}
+	for _, name := range sortedKeys(next.Probes) {
+		if _, ok := base.Probes[name]; !ok {
+			c.Changes = append(c.Changes, fmt.Sprintf("probe %s was added", name))
+		}
+	}
+
+	if changed, down := comparePercentages(base.ScoreContribution, next.ScoreContribution); changed != "" {
+		c.Changes = append(c.Changes, "score contribution "+changed)
+		c.Regression = c.Regression || down
+	}
+
+	if base.DurationSeconds != nil && next.DurationSeconds != nil {
+		delta := *next.DurationSeconds - *base.DurationSeconds
+		if math.Abs(delta) >= minDurationChangeSeconds && math.Abs(delta) >= *base.DurationSeconds*durationChangeRatio {
+			c.Changes = append(c.Changes, fmt.Sprintf("duration %.0fs → %.0fs", *base.DurationSeconds, *next.DurationSeconds))
+		}
+	}
+}
+
+// comparePercentages describes a change between two optional percentages and whether it went down.
+func comparePercentages(base, next *float64) (string, bool) {
+	switch {
+	case base == nil && next == nil:
+		return "", false
+	case base == nil:
+		return fmt.Sprintf("none → %g", *next), false
+	case next == nil:
+		return fmt.Sprintf("%g → none", *base), true
+	case *base != *next:
+		return fmt.Sprintf("%g → %g", *base, *next), *next < *base
+	}
+	return "", false
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
+
+// runWeights returns the fault weights of a run's experiment, keyed by fault name. Chaos Center only keeps the
+// current weights, so runs from before a weight change are scored with the new ones.
+func (s *LitmusChaosServer) runWeights(ctx context.Context, experimentID string) (map[string]int, error) {
+	getExperiment, err := s.fetchExperiment(ctx, experimentID)
+	if err != nil {
+		return nil, err
+	}
+
+	weights := map[string]int{}
+	for _, w := range getExperiment.ExperimentDetails.Weightages {
+		weights[w.FaultName] = w.Weightage
+	}
+	return weights, nil
+}
+
+// runOverview summarizes a run for the comparison.
+func runOverview(run *ExperimentRun) map[string]interface{} {
+	return map[string]interface{}{
+		"id":              run.ExperimentRunID,
+		"experimentId":    run.ExperimentID,
+		"experimentName":  run.ExperimentName,
+		"status":          run.Phase,
+		"resiliencyScore": run.ResiliencyScore,
+		"faultsSummary":   runFaultsSummary(run),
+		"sequence":        run.RunSequence,
+		"createdAt":       run.CreatedAt,
+	}
+}
+
+// compareExperimentRuns lines up the faults of two runs by name and reports, fault by fault, what changed
+// from the baseline run to the compared one, highlighting regressions.
+func (s *LitmusChaosServer) compareExperimentRuns(ctx context.Context, args map[string]interface{}) (*ToolResult, error) {
+	baselineRunID := getStringFromArgs(args, "baselineRunId", "")
+	comparisonRunID := getStringFromArgs(args, "comparisonRunId", "")
+	if baselineRunID == "" || comparisonRunID == "" {
+		return nil, fmt.Errorf("baselineRunId and comparisonRunId are required")
+	}
+
+	runs := make([]*ExperimentRun, 2)
+	executions := make([]*executionData, 2)
+	for i, runID := range []string{baselineRunID, comparisonRunID} {
+		run, err := s.fetchExperimentRun(ctx, runID, "")
+		if err != nil {
+			return nil, err
+		}
+		execution, err := parseExecutionData(run.ExecutionData)
+		if err != nil {
+			return nil, fmt.Errorf("experiment run %s: %w", runID, err)
+		}
+		runs[i] = run
+		executions[i] = execution
+	}
+
+	var notes []string
+	weights := map[string]map[string]int{}
+	for _, run := range runs {
+		if _, ok := weights[run.ExperimentID]; ok {
+			continue
+		}
+		runWeights, err := s.runWeights(ctx, run.ExperimentID)
+		if err != nil {
+			notes = append(notes, fmt.Sprintf("Fault weights of experiment %s are unavailable (%v); score contributions assume a weight of %d per fault", run.ExperimentID, err, defaultFaultWeight))
+		}
+		weights[run.ExperimentID] = runWeights
+	}
+	if runs[0].ExperimentID != runs[1].ExperimentID {
+		notes = append(notes, "The runs belong to different experiments; faults are lined up by name")
+	}
+
+	baseKeys, baseOutcomes := faultOutcomes(executions[0], weights[runs[0].ExperimentID])
+	nextKeys, nextOutcomes := faultOutcomes(executions[1], weights[runs[1].ExperimentID])
+
+	faults := []*faultComparison{}
+	regressions := []string{}
+	seen := map[string]bool{}
+	for _, key := range append(baseKeys, nextKeys...) {
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		comparison := &faultComparison{Fault: key, Baseline: baseOutcomes[key], Comparison: nextOutcomes[key]}
+		comparison.compare()
+		if comparison.Regression {
+			regressions = append(regressions, fmt.Sprintf("%s: %s", key, strings.Join(comparison.Changes, ", ")))
+		}
+		faults = append(faults, comparison)
+	}
+
+	var scoreDelta *float64
+	if runs[0].ResiliencyScore != nil && runs[1].ResiliencyScore != nil {
+		delta := *runs[1].ResiliencyScore - *runs[0].ResiliencyScore
+		scoreDelta = &delta
+	}
+
+	summary := fmt.Sprintf("%d of %d faults regressed", len(regressions), len(faults))
+	if scoreDelta != nil {
+		summary = fmt.Sprintf("Resiliency score %g → %g; %s", *runs[0].ResiliencyScore, *runs[1].ResiliencyScore, summary)
+	}
+
+	response := map[string]interface{}{
+		"summary":     summary,
+		"baseline":    runOverview(runs[0]),
+		"comparison":  runOverview(runs[1]),
+		"scoreDelta":  scoreDelta,
+		"regressions": regressions,
+		"faults":      faults,
+	}
+	if len(notes) > 0 {
+		response["notes"] = notes
+	}
+
+	responseJSON, _ := json.MarshalIndent(response, "", "  ")
+
+	return &ToolResult{
+		Content: []ContentItem{
+			{
+				Type: "text",
+				Text: string(responseJSON),
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Polling limits for wait_for_experiment_run
+const (
+	defaultRunWaitTimeout  = 10 * time.Minute
+	maxRunWaitTimeout      = time.Hour
+	defaultRunPollInterval = 10 * time.Second
+	minRunPollInterval     = 2 * time.Second
+)
+
+// terminalRunPhases are the experiment run phases after which a run no longer changes.
+var terminalRunPhases = map[string]bool{
+	"Completed":                    true,
+	"Completed_With_Error":         true,
+	"Completed_With_Probe_Failure": true,
+	"Stopped":                      true,
+	"Skipped":                      true,
+	"Error":                        true,
+	"Timeout":                      true,
+	"Terminated":                   true,
+}
+
+// GraphQL error messages Chaos Center uses for runs it does not know
+var runNotFoundMarkers = []string{"no documents", "not found"}
+
+// runNotFound reports whether a fetchExperimentRun error means Chaos Center does not know the run, rather than that
+// the request failed.
+func runNotFound(err error) bool {
+	if errors.Is(err, errMissingField) {
+		return true
+	}
+	if !errors.Is(err, errGraphQL) {
+		return false
+	}
+	message := strings.ToLower(err.Error())
+	for _, marker := range runNotFoundMarkers {
+		if strings.Contains(message, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// runFaultsSummary extracts the fault counters of an experiment run.
+func runFaultsSummary(run *ExperimentRun) map[string]interface{} {
+	return map[string]interface{}{
+		"passed":        run.FaultsPassed,
+		"failed":        run.FaultsFailed,
+		"awaited":       run.FaultsAwaited,
+		"stopped":       run.FaultsStopped,
+		"notApplicable": run.FaultsNa,
+		"total":         run.TotalFaults,
+	}
+}
+
+// summarizeFaultVerdicts lists the verdict of each fault recorded in a run's execution data. A run without
+// execution data has no verdicts yet; data that cannot be parsed is an error.
+func summarizeFaultVerdicts(executionData string) ([]faultVerdict, error) {
+	if executionData == "" {
+		return []faultVerdict{}, nil
+	}
+	execution, err := parseExecutionData(executionData)
+	if err != nil {
+		return []faultVerdict{}, err
+	}
+	return execution.report().Faults, nil
+}
+
+// waitForExperimentRun starts or attaches to an experiment run and polls it until it reaches a terminal phase or times out,
+// reporting fault progress to the client along the way.
+func (s *LitmusChaosServer) waitForExperimentRun(ctx context.Context, args map[string]interface{}) (*ToolResult, error) {
+	experimentID := getStringFromArgs(args, "experimentId", "")
+	experimentRunID := getStringFromArgs(args, "experimentRunId", "")
+	notifyID := getStringFromArgs(args, "notifyId", "")
+
+	if experimentID == "" && experimentRunID == "" && notifyID == "" {
+		return nil, fmt.Errorf("one of experimentId, notifyId or experimentRunId is required")
+	}
+
+	timeout := defaultRunWaitTimeout
+	if seconds := getIntFromArgs(args, "timeoutSeconds", 0); seconds > 0 {
+		timeout = time.Duration(seconds) * time.Second
+	}
+	if timeout > maxRunWaitTimeout {
+		timeout = maxRunWaitTimeout
+	}
+
+	pollInterval := defaultRunPollInterval
+	if seconds := getIntFromArgs(args, "pollIntervalSeconds", 0); seconds > 0 {
+		pollInterval = time.Duration(seconds) * time.Second
+	}
+	if pollInterval < minRunPollInterval {
+		pollInterval = minRunPollInterval
+	}
+
+	// Progress must increase with every notification, so only report when more faults have finished
+	lastProgress := -1.0
+
+	started := false
+	var findings []policyFinding
+	if experimentRunID == "" && notifyID == "" {
+		var preview *ToolResult
+		var err error
+		findings, preview, err = s.admitRun(ctx, experimentID, getStringFromArgs(args, "confirmationToken", ""))
+		if err != nil || preview != nil {
+			return preview, err
+		}
+
+		notifyID, err = s.startExperimentRun(ctx, experimentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start experiment %s: %w", experimentID, err)
+		}
+		started = true
+		reportProgress(ctx, 0, 0, fmt.Sprintf("Started experiment %s (notifyId %s)", experimentID, notifyID))
+		lastProgress = 0
+	}
+
+	deadline := time.Now().Add(timeout)
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	var run *ExperimentRun
+	var lastErr error
+	for {
+		current, err := s.fetchExperimentRun(ctx, experimentRunID, notifyID)
+		if err != nil {
+			// A freshly started run is not visible by notifyID until the infrastructure picks it up; any other
+			// failure, or one for a run ID, ends the wait
+			if experimentRunID != "" || run != nil || !runNotFound(err) {
+				return nil, err
+			}
+			lastErr = err
+		} else {
+			run, lastErr = current, nil
+			if run.ExperimentRunID != "" {
+				experimentRunID = run.ExperimentRunID
+			}
+
+			done := float64(run.FaultsPassed + run.FaultsFailed + run.FaultsStopped + run.FaultsNa)
+			if done > lastProgress {
+				reportProgress(ctx, done, float64(run.TotalFaults), fmt.Sprintf("Run %s: %s, %d passed, %d failed, %d awaited of %d faults",
+					experimentRunID, run.Phase, run.FaultsPassed, run.FaultsFailed, run.FaultsAwaited, run.TotalFaults))
+				lastProgress = done
+			}
+
+			if terminalRunPhases[run.Phase] {
+				break
+			}
+		}
+
+		if time.Now().After(deadline) {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+
+	if run == nil {
+		if lastErr != nil {
+			return nil, fmt.Errorf("experiment run did not become available within %s: %w", timeout, lastErr)
+		}
+		return nil, fmt.Errorf("experiment run did not become available within %s", timeout)
+	}
+
+	phase := run.Phase
+	completed := terminalRunPhases[phase]
+	message := fmt.Sprintf("Experiment run finished with phase %s", phase)
+	if !completed {
+		message = fmt.Sprintf("Timed out after %s waiting for the experiment run; last phase was %s", timeout, phase)
+	}
+
+	faults, faultsErr := summarizeFaultVerdicts(run.ExecutionData)
+
+	response := map[string]interface{}{
+		"completed":       completed,
+		"timedOut":        !completed,
+		"message":         message,
+		"started":         started,
+		"experimentId":    run.ExperimentID,
+		"experimentName":  run.ExperimentName,
+		"experimentRunId": experimentRunID,
+		"notifyId":        notifyID,
+		"status":          phase,
+		"resiliencyScore": run.ResiliencyScore,
+		"faultsSummary":   runFaultsSummary(run),
+		"faults":          faults,
+		"updatedAt":       run.UpdatedAt,
+	}
+	if faultsErr != nil {
+		response["executionError"] = faultsErr.Error()
+	}
+	if len(findings) > 0 {
+		response["policyFindings"] = findings
+	}
+
+	responseJSON, _ := json.MarshalIndent(response, "", "  ")
+
+	return &ToolResult{
+		Content: []ContentItem{
+			{
+				Type: "text",
+				Text: string(responseJSON),
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,478 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// graphql-ws (subscriptions-transport-ws) protocol settings
+const (
+	graphqlWSSubprotocol     = "graphql-ws"
+	subscriptionReadTimeout  = 60 * time.Second
+	subscriptionWriteTimeout = 10 * time.Second
+	subscriptionMinBackoff   = time.Second
+	subscriptionMaxBackoff   = time.Minute
+	liveEventBufferSize      = 50
+)
+
+// Aggregate resources carrying the most recent live events
+const (
+	experimentRunEventsURI = litmusURIScheme + "events/experiment-runs"
+	infraEventsURI         = litmusURIScheme + "events/infras"
+)
+
+// graphqlWSMessage is a message of the graphql-ws protocol.
+type graphqlWSMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// subscriptionClient runs GraphQL subscriptions against Chaos Center over WebSocket.
+type subscriptionClient struct {
+	url    string
+	auth   *tokenSource
+	dialer *websocket.Dialer
+}
+
+func newSubscriptionClient(config *LitmusConfig, auth *tokenSource) (*subscriptionClient, error) {
+	endpoint, err := url.Parse(config.ChaoscenterEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Chaos Center endpoint: %w", err)
+	}
+
+	switch endpoint.Scheme {
+	case "https":
+		endpoint.Scheme = "wss"
+	default:
+		endpoint.Scheme = "ws"
+	}
+	endpoint.Path = strings.TrimRight(endpoint.Path, "/") + "/query"
+
+	return &subscriptionClient{
+		url:  endpoint.String(),
+		auth: auth,
+		dialer: &websocket.Dialer{
+			HandshakeTimeout: 10 * time.Second,
+			Subprotocols:     []string{graphqlWSSubprotocol},
+			Proxy:            http.ProxyFromEnvironment,
+		},
+	}, nil
+}
+
+// subscribe opens a connection, starts a single subscription and calls handle with the data of every event
+// until ctx is cancelled, the server completes the subscription or the connection fails.
+func (c *subscriptionClient) subscribe(ctx context.Context, query string, variables map[string]interface{}, handle func(json.RawMessage)) error {
+	token, err := c.auth.get(ctx)
+	if err != nil {
+		return err
+	}
+
+	header := http.Header{}
+	if token != "" {
+		header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+
+	conn, _, err := c.dialer.DialContext(ctx, c.url, header)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", c.url, err)
+	}
+	defer conn.Close()
+
+	// Unblock reads when the caller goes away
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.SetWriteDeadline(time.Now().Add(subscriptionWriteTimeout))
+			conn.WriteJSON(graphqlWSMessage{ID: "1", Type: "stop"})
+			conn.WriteJSON(graphqlWSMessage{Type: "connection_terminate"})
+			conn.Close()
+		case <-stop:
+		}
+	}()
+
+	initPayload, _ := json.Marshal(map[string]interface{}{
+		"authorization": token,
+	})
+	if err := c.write(conn, graphqlWSMessage{Type: "connection_init", Payload: initPayload}); err != nil {
+		return err
+	}
+
+	for acked := false; !acked; {
+		msg, err := c.read(conn)
+		if err != nil {
+			return err
+		}
+		switch msg.Type {
+		case "connection_ack":
+			acked = true
+		case "ka":
+		case "connection_error":
+			if isAuthMessage(string(msg.Payload)) {
+				c.auth.invalidate(token)
+			}
+			return fmt.Errorf("subscription connection rejected: %s", string(msg.Payload))
+		default:
+			return fmt.Errorf("unexpected %q message during subscription handshake", msg.Type)
+		}
+	}
+
+	startPayload, err := json.Marshal(GraphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return fmt.Errorf("failed to marshal subscription: %w", err)
+	}
+	if err := c.write(conn, graphqlWSMessage{ID: "1", Type: "start", Payload: startPayload}); err != nil {
+		return err
+	}
+
+	for {
+		msg, err := c.read(conn)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		switch msg.Type {
+		case "data":
+			var gqlResp GraphQLResponse
+			if err := json.Unmarshal(msg.Payload, &gqlResp); err != nil {
+				log.Printf("Failed to parse subscription event: %v", err)
+				continue
+			}
+			if len(gqlResp.Errors) > 0 {
+				messages := make([]string, len(gqlResp.Errors))
+				for i, e := range gqlResp.Errors {
+					messages[i] = e.Message
+					if isAuthMessage(e.Message) {
+						c.auth.invalidate(token)
+					}
+				}
+				return fmt.Errorf("GraphQL subscription errors: %s", strings.Join(messages, ", "))
+			}
+			handle(gqlResp.Data)
+		case "error":
+			return fmt.Errorf("GraphQL subscription error: %s", string(msg.Payload))
+		case "complete":
+			return nil
+		case "ka":
+		}
+	}
+}
+
+func (c *subscriptionClient) write(conn *websocket.Conn, msg graphqlWSMessage) error {
+	conn.SetWriteDeadline(time.Now().Add(subscriptionWriteTimeout))
+	if err := conn.WriteJSON(msg); err != nil {
+		return fmt.Errorf("failed to send %s message: %w", msg.Type, err)
+	}
+	return nil
+}
+
+func (c *subscriptionClient) read(conn *websocket.Conn) (*graphqlWSMessage, error) {
+	conn.SetReadDeadline(time.Now().Add(subscriptionReadTimeout))
+	var msg graphqlWSMessage
+	if err := conn.ReadJSON(&msg); err != nil {
+		return nil, fmt.Errorf("failed to read subscription message: %w", err)
+	}
+	return &msg, nil
+}
+
+// run keeps a subscription alive, reconnecting with exponential backoff until ctx is cancelled.
+func (c *subscriptionClient) run(ctx context.Context, name, query string, variables map[string]interface{}, handle func(json.RawMessage)) {
+	backoff := subscriptionMinBackoff
+	for {
+		connectedAt := time.Now()
+		err := c.subscribe(ctx, query, variables, handle)
+		if ctx.Err() != nil {
+			return
+		}
+
+		// A subscription that stayed up for a while resets the backoff
+		if time.Since(connectedAt) > subscriptionMaxBackoff {
+			backoff = subscriptionMinBackoff
+		}
+		log.Printf("Subscription %s ended (%v), reconnecting in %s", name, err, backoff)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > subscriptionMaxBackoff {
+			backoff = subscriptionMaxBackoff
+		}
+	}
+}
+
+// resourceSubscriptions tracks which client sessions subscribed to which resource URIs.
+type resourceSubscriptions struct {
+	mu          sync.Mutex
+	subscribers map[string]map[string]notifyFunc
+}
+
+func newResourceSubscriptions() *resourceSubscriptions {
+	return &resourceSubscriptions{
+		subscribers: make(map[string]map[string]notifyFunc),
+	}
+}
+
+func (rs *resourceSubscriptions) add(uri string, session *clientSession) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if rs.subscribers[uri] == nil {
+		rs.subscribers[uri] = make(map[string]notifyFunc)
+	}
+	rs.subscribers[uri][session.id] = session.notify
+}
+
+func (rs *resourceSubscriptions) remove(uri, sessionID string) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	delete(rs.subscribers[uri], sessionID)
+	if len(rs.subscribers[uri]) == 0 {
+		delete(rs.subscribers, uri)
+	}
+}
+
+// removeSession drops every subscription held by a session that has gone away.
+func (rs *resourceSubscriptions) removeSession(sessionID string) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	for uri, sessions := range rs.subscribers {
+		delete(sessions, sessionID)
+		if len(sessions) == 0 {
+			delete(rs.subscribers, uri)
+		}
+	}
+}
+
+// publish sends notifications/resources/updated for uri to every subscribed session.
+func (rs *resourceSubscriptions) publish(uri string) {
+	rs.mu.Lock()
+	notifiers := make([]notifyFunc, 0, len(rs.subscribers[uri]))
+	for _, notify := range rs.subscribers[uri] {
+		notifiers = append(notifiers, notify)
+	}
+	rs.mu.Unlock()
+
+	for _, notify := range notifiers {
+		notify(&MCPNotification{
+			JSONRPC: "2.0",
+			Method:  "notifications/resources/updated",
+			Params:  map[string]interface{}{"uri": uri},
+		})
+	}
+}
+
+// liveEvents receives experiment run and infrastructure events from Chaos Center subscriptions
+// and turns them into resource update notifications.
+type liveEvents struct {
+	server *LitmusChaosServer
+
+	mu          sync.Mutex
+	ctx         context.Context
+	started     map[string]bool
+	runEvents   []ExperimentRun
+	infraEvents []InfraEvent
+}
+
+// bind ties the Chaos Center subscriptions to the server's run context, so they stop on shutdown.
+func (le *liveEvents) bind(ctx context.Context) {
+	le.mu.Lock()
+	defer le.mu.Unlock()
+	le.ctx = ctx
+}
+
+// start launches the Chaos Center subscriptions of the default project and every configured project the first
+// time any client subscribes to a resource, and of p if it was not among them.
+func (le *liveEvents) start(p *project) {
+	server := le.server
+	le.startProject(server.resolveProject(server.config.ProjectID))
+	for _, configured := range server.projects {
+		le.startProject(configured)
+	}
+	le.startProject(p)
+}
+
+// startProject subscribes to the experiment and infrastructure events of one project, once per project ID.
+func (le *liveEvents) startProject(p *project) {
+	if p == nil || p.ID == "" {
+		return
+	}
+
+	le.mu.Lock()
+	defer le.mu.Unlock()
+
+	if le.started[p.ID] {
+		return
+	}
+	if le.started == nil {
+		le.started = map[string]bool{}
+	}
+	le.started[p.ID] = true
+
+	client, err := newSubscriptionClient(le.server.config, p.auth)
+	if err != nil {
+		log.Printf("Live events of project %s disabled: %v", p.ID, err)
+		return
+	}
+
+	experimentEvents := `
+		subscription GetExperimentEvents($projectID: String!) {
+			getExperimentEvents(projectID: $projectID) {
+				experimentRunID
+				experimentID
+				experimentName
+				phase
+				resiliencyScore
+				faultsPassed
+				faultsFailed
+				faultsAwaited
+				faultsStopped
+				faultsNa
+				totalFaults
+				updatedAt
+				infra {
+					infraID
+					name
+				}
+			}
+		}
+	`
+
+	infraEvents := `
+		subscription GetInfraEvents($projectID: String!) {
+			getInfraEvents(projectID: $projectID) {
+				eventID
+				eventType
+				eventName
+				infra {
+					infraID
+					name
+					isActive
+					isInfraConfirmed
+				}
+			}
+		}
+	`
+
+	ctx := le.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	variables := map[string]interface{}{"projectID": p.ID}
+	go client.run(ctx, "getExperimentEvents/"+p.ID, experimentEvents, variables, le.handleExperimentEvent)
+	go client.run(ctx, "getInfraEvents/"+p.ID, infraEvents, variables, le.handleInfraEvent)
+}
+
+func (le *liveEvents) handleExperimentEvent(data json.RawMessage) {
+	var event ExperimentRun
+	if err := decodeField(data, "getExperimentEvents", &event); err != nil {
+		log.Printf("Failed to parse experiment event: %v", err)
+		return
+	}
+
+	le.mu.Lock()
+	le.runEvents = appendBounded(le.runEvents, event)
+	le.mu.Unlock()
+
+	subscriptions := le.server.subscriptions
+	subscriptions.publish(experimentRunEventsURI)
+	if event.ExperimentRunID != "" {
+		subscriptions.publish(litmusURIScheme + "runs/" + event.ExperimentRunID + "/manifest")
+	}
+	subscriptions.publish(litmusURIScheme + "experiments/" + event.ExperimentID)
+}
+
+func (le *liveEvents) handleInfraEvent(data json.RawMessage) {
+	var event InfraEvent
+	if err := decodeField(data, "getInfraEvents", &event); err != nil {
+		log.Printf("Failed to parse infrastructure event: %v", err)
+		return
+	}
+
+	le.mu.Lock()
+	le.infraEvents = appendBounded(le.infraEvents, event)
+	le.mu.Unlock()
+
+	subscriptions := le.server.subscriptions
+	subscriptions.publish(infraEventsURI)
+	if event.Infra != nil && event.Infra.InfraID != "" {
+		subscriptions.publish(litmusURIScheme + "infras/" + event.Infra.InfraID + "/manifest")
+	}
+}
+
+// snapshot returns the buffered events behind one of the aggregate event resources, newest first.
+func (le *liveEvents) snapshot(uri string) interface{} {
+	le.mu.Lock()
+	defer le.mu.Unlock()
+
+	if uri == infraEventsURI {
+		return newestFirst(le.infraEvents)
+	}
+	return newestFirst(le.runEvents)
+}
+
+func appendBounded[T any](events []T, event T) []T {
+	events = append(events, event)
+	if len(events) > liveEventBufferSize {
+		events = events[len(events)-liveEventBufferSize:]
+	}
+	return events
+}
+
+func newestFirst[T any](events []T) []T {
+	reversed := make([]T, len(events))
+	for i, event := range events {
+		reversed[len(events)-1-i] = event
+	}
+	return reversed
+}
+
+// handleSubscribeResource registers the requesting session for notifications/resources/updated on a URI.
+func (s *LitmusChaosServer) handleSubscribeResource(ctx context.Context, params json.RawMessage, subscribe bool) (interface{}, error) {
+	var subParams struct {
+		URI string `json:"uri"`
+	}
+
+	if err := json.Unmarshal(params, &subParams); err != nil || subParams.URI == "" {
+		return nil, fmt.Errorf("%w: uri is required", errInvalidParams)
+	}
+
+	session := sessionFromContext(ctx)
+	if session == nil {
+		return nil, fmt.Errorf("resource subscriptions are not supported on this transport")
+	}
+
+	if !subscribe {
+		s.subscriptions.remove(subParams.URI, session.id)
+		return map[string]interface{}{}, nil
+	}
+
+	if !strings.HasPrefix(subParams.URI, litmusURIScheme) {
+		return nil, fmt.Errorf("%w: %s", errResourceNotFound, subParams.URI)
+	}
+	if err := s.checkResource(subParams.URI); err != nil {
+		return nil, err
+	}
+
+	s.subscriptions.add(subParams.URI, session)
+	s.events.start(s.projectFromContext(ctx))
+
+	return map[string]interface{}{}, nil
+}
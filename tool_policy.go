@@ -0,0 +1,181 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// errToolNotAllowed is returned by tools/call for tools the server's configuration does not permit.
+var errToolNotAllowed = errors.New("tool not allowed")
+
+// Access classes usable in the allow and deny lists next to tool names and categories
+const (
+	accessRead  = "read"
+	accessWrite = "write"
+)
+
+// toolInfo classifies a tool for the allow and deny lists.
+type toolInfo struct {
+	category string
+	mutating bool
+}
+
+// toolCatalog classifies every tool. Tools missing from it are treated as mutating.
+var toolCatalog = map[string]toolInfo{
+	"list_projects":                 {category: "projects"},
+	"list_chaos_experiments":        {category: "experiments"},
+	"get_chaos_experiment":          {category: "experiments"},
+	"create_chaos_experiment":       {category: "experiments", mutating: true},
+	"update_chaos_experiment":       {category: "experiments", mutating: true},
+	"delete_chaos_experiment":       {category: "experiments", mutating: true},
+	"clone_chaos_experiment":        {category: "experiments", mutating: true},
+	"enable_experiment_schedule":    {category: "experiments", mutating: true},
+	"disable_experiment_schedule":   {category: "experiments", mutating: true},
+	"export_chaos_experiment":       {category: "experiments"},
+	"import_chaos_experiment":       {category: "experiments", mutating: true},
+	"plan_chaos_changes":            {category: "experiments"},
+	"apply_chaos_changes":           {category: "experiments", mutating: true},
+	"run_chaos_experiment":          {category: "runs", mutating: true},
+	"wait_for_experiment_run":       {category: "runs", mutating: true},
+	"stop_chaos_experiment":         {category: "runs", mutating: true},
+	"list_experiment_runs":          {category: "runs"},
+	"get_experiment_run_details":    {category: "runs"},
+	"compare_experiment_runs":       {category: "runs"},
+	"get_fault_logs":                {category: "runs"},
+	"list_chaos_infrastructures":    {category: "infrastructure"},
+	"get_infrastructure_details":    {category: "infrastructure"},
+	"register_chaos_infrastructure": {category: "infrastructure", mutating: true},
+	"list_environments":             {category: "environments"},
+	"create_environment":            {category: "environments", mutating: true},
+	"list_resilience_probes":        {category: "probes"},
+	"create_resilience_probe":       {category: "probes", mutating: true},
+	"list_chaos_hubs":               {category: "hubs"},
+	"get_chaos_faults":              {category: "hubs"},
+	"get_experiment_statistics":     {category: "statistics"},
+	"get_resilience_trends":         {category: "statistics"},
+}
+
+// toolPolicy decides which tools are listed and may be called.
+type toolPolicy struct {
+	readOnly bool
+	allowed  []string
+	denied   []string
+}
+
+// newToolPolicy builds the policy from the read-only flag and the allow and deny lists,
+// warning about entries that match no tool, category or access class.
+func newToolPolicy(readOnly bool, allowed, denied []string) *toolPolicy {
+	p := &toolPolicy{
+		readOnly: readOnly,
+		allowed:  normalizePolicyEntries(allowed),
+		denied:   normalizePolicyEntries(denied),
+	}
+
+	for _, entry := range append(append([]string{}, p.allowed...), p.denied...) {
+		if !knownPolicyEntry(entry) {
+			log.Printf("Tool policy entry %q matches no tool, category or access class", entry)
+		}
+	}
+	return p
+}
+
+func normalizePolicyEntries(entries []string) []string {
+	var normalized []string
+	for _, entry := range entries {
+		if entry = strings.ToLower(strings.TrimSpace(entry)); entry != "" {
+			normalized = append(normalized, entry)
+		}
+	}
+	return normalized
+}
+
+func knownPolicyEntry(entry string) bool {
+	if entry == accessRead || entry == accessWrite {
+		return true
+	}
+	for name, info := range toolCatalog {
+		if entry == name || entry == info.category {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPolicyEntry returns the first entry that selects the tool, by name, category or access class.
+func matchPolicyEntry(entries []string, name string, info toolInfo, mutating bool) (string, bool) {
+	access := accessRead
+	if mutating {
+		access = accessWrite
+	}
+	for _, entry := range entries {
+		if entry == name || entry == info.category || entry == access {
+			return entry, true
+		}
+	}
+	return "", false
+}
+
+// lookupTool returns the classification of a tool, treating unknown tools as mutating.
+func lookupTool(name string) toolInfo {
+	if info, ok := toolCatalog[name]; ok {
+		return info
+	}
+	return toolInfo{mutating: true}
+}
+
+// isMutatingCall reports whether a call changes anything in Chaos Center. wait_for_experiment_run only
+// starts a run when it is not given one to attach to.
+func isMutatingCall(name string, args map[string]interface{}) bool {
+	if name == "wait_for_experiment_run" {
+		return getStringFromArgs(args, "experimentRunId", "") == "" && getStringFromArgs(args, "notifyId", "") == ""
+	}
+	return lookupTool(name).mutating
+}
+
+// check returns an errToolNotAllowed error explaining why a call is blocked, or nil if it may run.
+func (p *toolPolicy) check(name string, args map[string]interface{}) error {
+	return p.decide(name, isMutatingCall(name, args))
+}
+
+// listed reports whether clients see the tool. Tools that can attach to existing work stay listed in
+// read-only mode; only their mutating calls are blocked.
+func (p *toolPolicy) listed(name string) bool {
+	mutating := lookupTool(name).mutating
+	if name == "wait_for_experiment_run" {
+		mutating = false
+	}
+	return p.decide(name, mutating) == nil
+}
+
+func (p *toolPolicy) decide(name string, mutating bool) error {
+	info := lookupTool(name)
+
+	if p.readOnly && mutating {
+		if name == "wait_for_experiment_run" {
+			return fmt.Errorf("%w: %s can only attach to an existing run (experimentRunId or notifyId) because the server is in read-only mode (LITMUS_MCP_READ_ONLY)", errToolNotAllowed, name)
+		}
+		return fmt.Errorf("%w: %s changes Chaos Center and the server is in read-only mode (LITMUS_MCP_READ_ONLY)", errToolNotAllowed, name)
+	}
+	if entry, ok := matchPolicyEntry(p.denied, name, info, mutating); ok {
+		return fmt.Errorf("%w: %s is blocked by %q in LITMUS_MCP_DENIED_TOOLS", errToolNotAllowed, name, entry)
+	}
+	if len(p.allowed) > 0 {
+		if _, ok := matchPolicyEntry(p.allowed, name, info, mutating); !ok {
+			return fmt.Errorf("%w: %s is not included in LITMUS_MCP_ALLOWED_TOOLS", errToolNotAllowed, name)
+		}
+	}
+	return nil
+}
+
+// visibleTools returns the tools the policy lets clients see.
+func (p *toolPolicy) visibleTools(tools []Tool) []Tool {
+	visible := make([]Tool, 0, len(tools))
+	for _, tool := range tools {
+		if p.listed(tool.Name) {
+			visible = append(visible, tool)
+		}
+	}
+	return visible
+}
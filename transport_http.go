@@ -0,0 +1,530 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Streamable HTTP transport settings
+const (
+	mcpEndpointPath      = "/mcp"
+	mcpSessionHeader     = "Mcp-Session-Id"
+	maxRequestBodyBytes  = 4 << 20
+	sseKeepAliveInterval = 25 * time.Second
+	sessionIdleTimeout   = 30 * time.Minute
+)
+
+// HTTPTransportConfig configures the MCP Streamable HTTP transport.
+type HTTPTransportConfig struct {
+	Addr           string
+	AuthToken      string
+	AllowedOrigins []string
+}
+
+// httpSession tracks a client session established by initialize and its open SSE streams.
+type httpSession struct {
+	id       string
+	mu       sync.Mutex
+	lastSeen time.Time
+	streams  map[chan []byte]struct{}
+	requests *requestTracker
+}
+
+// touch records activity on the session.
+func (hs *httpSession) touch() {
+	hs.mu.Lock()
+	hs.lastSeen = time.Now()
+	hs.mu.Unlock()
+}
+
+// send delivers a server-initiated message to every open GET stream of the session.
+// It returns false when no stream could accept the message.
+func (hs *httpSession) send(msg interface{}) bool {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("Failed to marshal session message: %v", err)
+		return false
+	}
+
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+
+	delivered := false
+	for stream := range hs.streams {
+		select {
+		case stream <- payload:
+			delivered = true
+		default:
+		}
+	}
+	return delivered
+}
+
+// close terminates all open streams of the session.
+func (hs *httpSession) close() {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	for stream := range hs.streams {
+		close(stream)
+		delete(hs.streams, stream)
+	}
+}
+
+// httpTransport serves the MCP Streamable HTTP transport on top of the shared handleRequest dispatch.
+type httpTransport struct {
+	server         *LitmusChaosServer
+	authToken      string
+	allowedOrigins map[string]bool
+
+	mu       sync.Mutex
+	sessions map[string]*httpSession
+}
+
+func newHTTPTransport(server *LitmusChaosServer, cfg HTTPTransportConfig) *httpTransport {
+	origins := map[string]bool{}
+	for _, origin := range cfg.AllowedOrigins {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			origins[strings.TrimRight(origin, "/")] = true
+		}
+	}
+
+	return &httpTransport{
+		server:         server,
+		authToken:      cfg.AuthToken,
+		allowedOrigins: origins,
+		sessions:       make(map[string]*httpSession),
+	}
+}
+
+// runHTTP serves MCP over Streamable HTTP until ctx is cancelled, then drains in-flight requests.
+func (s *LitmusChaosServer) runHTTP(ctx context.Context, cfg HTTPTransportConfig) error {
+	s.events.bind(ctx)
+
+	transport := newHTTPTransport(s, cfg)
+	go transport.expireSessions(ctx)
+
+	mux := http.NewServeMux()
+	mux.Handle(mcpEndpointPath, transport)
+
+	httpServer := &http.Server{
+		Addr:              cfg.Addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	// SSE streams never go idle on their own, so close them before waiting on active requests
+	transport.closeSessions()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownGracePeriod)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shut down http transport: %w", err)
+	}
+	return nil
+}
+
+func (t *httpTransport) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !t.originAllowed(r) {
+		http.Error(w, "Forbidden: origin not allowed", http.StatusForbidden)
+		return
+	}
+
+	if !t.authorized(r) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		t.handlePost(w, r)
+	case http.MethodGet:
+		t.handleGet(w, r)
+	case http.MethodDelete:
+		t.handleDelete(w, r)
+	default:
+		w.Header().Set("Allow", "GET, POST, DELETE")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// originAllowed rejects cross-origin browser requests unless the origin matches the host or is explicitly allowed.
+func (t *httpTransport) originAllowed(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	if t.allowedOrigins[strings.TrimRight(origin, "/")] || t.allowedOrigins["*"] {
+		return true
+	}
+	parsed, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return parsed.Host == r.Host
+}
+
+// authorized checks the optional bearer token shared by MCP clients of this server.
+func (t *httpTransport) authorized(r *http.Request) bool {
+	if t.authToken == "" {
+		return true
+	}
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return subtle.ConstantTimeCompare([]byte(token), []byte(t.authToken)) == 1
+}
+
+// lookupSession returns the session named by the Mcp-Session-Id header, writing the error response when it is missing or unknown.
+func (t *httpTransport) lookupSession(w http.ResponseWriter, r *http.Request) *httpSession {
+	id := r.Header.Get(mcpSessionHeader)
+	if id == "" {
+		http.Error(w, "Bad Request: missing "+mcpSessionHeader+" header", http.StatusBadRequest)
+		return nil
+	}
+
+	t.mu.Lock()
+	session := t.sessions[id]
+	t.mu.Unlock()
+
+	if session == nil {
+		http.Error(w, "Session not found", http.StatusNotFound)
+		return nil
+	}
+
+	session.touch()
+	return session
+}
+
+func (t *httpTransport) newSession() *httpSession {
+	session := &httpSession{
+		id:       newUUID(),
+		lastSeen: time.Now(),
+		streams:  make(map[chan []byte]struct{}),
+		requests: newRequestTracker(),
+	}
+
+	t.mu.Lock()
+	t.sessions[session.id] = session
+	t.mu.Unlock()
+
+	return session
+}
+
+// closeSessions terminates every session and its open streams.
+func (t *httpTransport) closeSessions() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for id, session := range t.sessions {
+		session.close()
+		delete(t.sessions, id)
+		t.server.endSession(id)
+	}
+}
+
+// expireSessions drops sessions that have been idle for longer than sessionIdleTimeout.
+func (t *httpTransport) expireSessions(ctx context.Context) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		cutoff := time.Now().Add(-sessionIdleTimeout)
+
+		t.mu.Lock()
+		for id, session := range t.sessions {
+			session.mu.Lock()
+			idle := session.lastSeen.Before(cutoff) && len(session.streams) == 0
+			session.mu.Unlock()
+			if idle {
+				delete(t.sessions, id)
+				t.server.endSession(id)
+			}
+		}
+		t.mu.Unlock()
+	}
+}
+
+// handlePost processes a JSON-RPC message or batch sent by the client and replies as JSON or as an SSE stream.
+func (t *httpTransport) handlePost(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestBodyBytes))
+	if err != nil {
+		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	body = bytes.TrimSpace(body)
+	batch := len(body) > 0 && body[0] == '['
+
+	var requests []*MCPRequest
+	if batch {
+		err = json.Unmarshal(body, &requests)
+	} else {
+		var req MCPRequest
+		err = json.Unmarshal(body, &req)
+		requests = []*MCPRequest{&req}
+	}
+	if err != nil || len(requests) == 0 {
+		writeJSON(w, http.StatusBadRequest, &MCPResponse{
+			JSONRPC: "2.0",
+			Error:   &MCPError{Code: codeParseError, Message: "Parse error"},
+		})
+		return
+	}
+
+	initializing := false
+	hasRequests := false
+	for _, req := range requests {
+		if req.Method == "initialize" {
+			initializing = true
+		}
+		if req.Method != "" && req.ID != nil {
+			hasRequests = true
+		}
+	}
+
+	var session *httpSession
+	if initializing {
+		if batch {
+			http.Error(w, "Bad Request: initialize must not be part of a batch", http.StatusBadRequest)
+			return
+		}
+		session = t.newSession()
+		w.Header().Set(mcpSessionHeader, session.id)
+	} else if session = t.lookupSession(w, r); session == nil {
+		return
+	}
+
+	if !hasRequests {
+		for _, req := range requests {
+			switch req.Method {
+			case "":
+			case "notifications/cancelled":
+				session.requests.handleCancelled(req.Params)
+			default:
+				t.server.handleRequest(r.Context(), req)
+			}
+		}
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	if acceptsEventStream(r) {
+		t.streamResponses(w, r, session, requests)
+		return
+	}
+
+	responses := t.dispatch(r.Context(), session, requests)
+	if batch {
+		writeJSON(w, http.StatusOK, responses)
+	} else if len(responses) > 0 {
+		writeJSON(w, http.StatusOK, responses[0])
+	}
+}
+
+// dispatch runs each message through handleRequest and collects the responses to requests.
+func (t *httpTransport) dispatch(ctx context.Context, session *httpSession, requests []*MCPRequest) []*MCPResponse {
+	responses := make([]*MCPResponse, 0, len(requests))
+	for _, req := range requests {
+		if resp := t.handleOne(ctx, session, req); resp != nil {
+			responses = append(responses, resp)
+		}
+	}
+	return responses
+}
+
+// handleOne runs a single message through handleRequest, tracking requests so the client can cancel them.
+// Notifications, client responses and cancelled requests yield no response.
+func (t *httpTransport) handleOne(ctx context.Context, session *httpSession, req *MCPRequest) *MCPResponse {
+	if req.Method == "" {
+		return nil
+	}
+	if req.ID == nil {
+		if req.Method == "notifications/cancelled" {
+			session.requests.handleCancelled(req.Params)
+		} else {
+			t.server.handleRequest(ctx, req)
+		}
+		return nil
+	}
+
+	ctx = withSession(ctx, session.id, func(msg *MCPNotification) {
+		session.send(msg)
+	})
+	reqCtx, done := session.requests.begin(ctx, req.ID)
+	defer done()
+	if _, ok := reqCtx.Value(notifierKey{}).(notifyFunc); !ok {
+		// Without a response stream, notifications go out on the session's GET stream
+		reqCtx = withNotifier(reqCtx, func(msg *MCPNotification) {
+			session.send(msg)
+		})
+	}
+
+	resp := t.server.handleRequest(reqCtx, req)
+	if reqCtx.Err() != nil {
+		return nil
+	}
+	return resp
+}
+
+// streamResponses answers a POST with an SSE stream carrying one event per JSON-RPC response.
+func (t *httpTransport) streamResponses(w http.ResponseWriter, r *http.Request, session *httpSession, requests []*MCPRequest) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeJSON(w, http.StatusOK, t.dispatch(r.Context(), session, requests))
+		return
+	}
+
+	setSSEHeaders(w)
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	var writeMu sync.Mutex
+	ctx := withNotifier(r.Context(), func(msg *MCPNotification) {
+		payload, err := json.Marshal(msg)
+		if err != nil {
+			log.Printf("Failed to marshal notification: %v", err)
+			return
+		}
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		writeSSEEvent(w, payload)
+		flusher.Flush()
+	})
+
+	for _, req := range requests {
+		resp := t.handleOne(ctx, session, req)
+		if resp == nil {
+			continue
+		}
+		payload, err := json.Marshal(resp)
+		if err != nil {
+			log.Printf("Failed to marshal response: %v", err)
+			continue
+		}
+		writeMu.Lock()
+		writeSSEEvent(w, payload)
+		flusher.Flush()
+		writeMu.Unlock()
+	}
+}
+
+// handleGet opens a long-lived SSE stream for server-initiated messages on an existing session.
+func (t *httpTransport) handleGet(w http.ResponseWriter, r *http.Request) {
+	if !acceptsEventStream(r) {
+		http.Error(w, "Not Acceptable: client must accept text/event-stream", http.StatusNotAcceptable)
+		return
+	}
+
+	session := t.lookupSession(w, r)
+	if session == nil {
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	stream := make(chan []byte, 32)
+	session.mu.Lock()
+	session.streams[stream] = struct{}{}
+	session.mu.Unlock()
+
+	defer func() {
+		session.mu.Lock()
+		if _, open := session.streams[stream]; open {
+			delete(session.streams, stream)
+			close(stream)
+		}
+		session.lastSeen = time.Now()
+		session.mu.Unlock()
+	}()
+
+	setSSEHeaders(w)
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(sseKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case payload, open := <-stream:
+			if !open {
+				return
+			}
+			writeSSEEvent(w, payload)
+			flusher.Flush()
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+// handleDelete terminates a session at the client's request.
+func (t *httpTransport) handleDelete(w http.ResponseWriter, r *http.Request) {
+	session := t.lookupSession(w, r)
+	if session == nil {
+		return
+	}
+
+	t.mu.Lock()
+	delete(t.sessions, session.id)
+	t.mu.Unlock()
+
+	session.close()
+	t.server.endSession(session.id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func acceptsEventStream(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+}
+
+func setSSEHeaders(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+}
+
+func writeSSEEvent(w io.Writer, payload []byte) {
+	fmt.Fprintf(w, "event: message\ndata: %s\n\n", payload)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Failed to write response: %v", err)
+	}
+}